@@ -1,6 +1,10 @@
 // Code generated for package main by go-bindata DO NOT EDIT. (@generated)
 // sources:
+// benchdiff-template.html
+// diff-template.html
+// history-template.html
 // report-template.html
+// source-template.html
 package assets
 
 import (
@@ -77,7 +81,67 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _reportTemplateHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x57\x4b\x93\xdb\x36\x0c\xbe\xef\xaf\x40\xd5\x74\xe2\x9d\x44\xf2\xa3\x69\x27\x23\x4b\x3e\x64\x93\x34\x87\xb4\xc9\x34\xee\xa1\xd3\xf4\x40\x8b\xb0\xcc\x35\x45\x6a\x48\xc8\x8f\xee\xf8\xbf\x77\xa8\x87\x2d\xcb\xda\x64\x93\xd5\x81\x3b\x24\x81\x0f\xc0\x07\x02\x58\x47\x3f\xbc\xfe\x70\x33\xff\xfb\xe3\x1b\x58\x51\x26\x67\x57\x91\xfb\x03\x92\xa9\x34\xf6\x50\x79\xee\x00\x19\x9f\x5d\x01\x00\x44\x19\x12\x83\x64\xc5\x8c\x45\x8a\xbd\xbf\xe6\x6f\xfd\x97\x5e\x7d\x45\x82\x24\xce\xe6\x6e\x8d\x86\xd5\xa6\xba\xb0\xb4\x97\x08\xb4\xcf\x31\xf6\x08\x77\x34\x4c\xac\xad\x95\xdc\x17\x18\xad\x09\xee\x8e\x7b\xf7\x2d\x58\xb2\x4e\x8d\x2e\x14\xf7\x13\x2d\xb5\x09\xe1\xc7\xc9\xcf\x93\xd1\x8b\xf1\xf4\x4c\xac\xbe\xdb\xae\x04\xe1\xe9\xe6\x70\x75\xc2\xb6\x45\x92\xa0\xb5\xaf\x8e\x78\x37\x4e\xe5\xab\xd6\x38\x33\xeb\xd4\x20\xaa\x7e\xd4\x25\x13\xf2\x7b\x20\x0d\xf2\x7b\xdc\x5c\x8b\xfc\x3b\x7d\xdc\xf7\x23\xe6\x2c\x59\xb3\x14\x6f\x98\xe1\xef\xd9\x5e\x17\x5d\x86\x53\x23\xb8\x4f\x98\xe5\x92\x11\x3a\xc8\x22\x53\x36\x84\xf1\xd2\x00\x2b\x48\x9f\x96\xe9\xa5\x5a\x25\xed\xa7\x2c\x0f\xe1\x65\xbe\x3b\x97\xe0\xc2\xe6\x92\xed\xc3\x52\xb4\xdf\x37\x42\x4b\x8f\x72\xec\x41\x16\xdd\xb7\x15\x9c\x56\x21\x8c\x47\xa3\x9f\x3a\x71\xf4\xfa\xbe\xd0\x86\xa3\xf1\x0d\xe3\xa2\xb0\x21\xbc\xe8\xde\x67\xcc\xa4\x42\xf9\x0b\x4d\xa4\xb3\x10\x7e\xe9\xde\xe7\x8c\x73\xa1\xd2\x8e\x66\x3b\xf4\x44\x4b\xc9\x72\x2b\x16\x12\x3b\x71\x27\x85\xb1\x2e\xad\xb9\x16\x8a\xd0\x7c\x55\xfd\x1d\x32\x67\xab\x8b\xd2\x5b\x11\x67\xbe\x5d\x44\x7d\x2f\x49\x15\x1d\x21\x28\xad\x3a\x60\xae\x8c\x7d\x26\x45\xaa\x42\x90\xb8\xa4\xf3\x5b\x5d\x90\x14\x0a\xfb\x14\x97\x5a\x91\x6f\xc5\x7f\x18\xc2\xf8\x82\xbe\xc7\xd0\xff\x65\x96\x42\xb6\x24\xec\x96\x54\xa2\x15\xa1\xa2\x10\x9e\x7e\x1e\x8d\x26\xaf\x9e\xf6\x83\xb1\x84\xc4\x06\xbf\x0c\xe0\x7d\x9e\x4c\xc6\x13\xef\xa1\xde\xdc\x54\x7a\x1d\xb4\x63\x82\x46\x30\xbe\xc8\x51\xc6\x76\xfe\x0a\x45\xba\xa2\x10\x46\x1d\xb6\x37\x68\x96\x52\x6f\x43\x58\x09\xce\xdb\x2d\xab\xcc\x94\x61\xca\x0a\x12\x5a\x85\x2d\x10\x18\x05\x13\x0b\xc8\x2c\xfa\xba\xa0\xfb\xab\xf4\x13\x31\xb2\x1f\x36\x68\x36\x02\xb7\x0f\x2f\xd4\xa6\x58\xbf\xb9\x33\xe4\xcc\x5a\xe4\x73\xb4\x64\x3b\xd6\x5a\x0f\x67\xe7\x4b\x66\x52\xec\x1d\x05\x5f\x69\xda\x8f\xc2\xee\x74\x6f\xb7\x46\xc3\x72\xb8\xcd\xae\xa2\x61\x35\x24\xa3\x85\xe6\x7b\x48\x24\xb3\x36\xf6\xdc\x60\x73\xf3\x93\x8b\x0d\x94\x72\xb1\x77\xe4\x60\x29\x71\x37\x2d\x57\x9f\x0b\x83\x49\x95\xa1\x8a\xc5\x69\xfd\xd2\x43\x18\xff\x9a\xef\xa6\xd0\x24\x7e\x3c\x1a\x6d\x56\xcd\xb8\x6d\x81\xb6\xfc\x2f\xbd\xf7\x66\xbf\x69\x70\x81\xc2\x9f\x98\x6b\x43\xd1\x90\x8b\xcd\x43\xd4\x4a\x9d\xd7\x8c\x30\x84\xbb\xbb\xc0\xed\xdc\xe6\x70\xe8\x02\xd4\xf1\x5d\xbc\x90\xd6\x54\x8f\xf2\xc6\x4c\x5d\xb5\xa4\x73\xf7\x74\xbd\x46\xb9\x95\x6a\x6f\xf6\xb1\xdc\x80\x03\xb4\xa5\xed\x8f\xa7\x5b\x67\x3e\xff\x06\xe0\x56\x9e\xbd\xd9\xdb\x72\xd3\x02\x7e\x7b\xba\xbd\x17\xb8\xe7\x3d\x40\xc7\xd8\x6c\xae\x89\xc9\x12\x17\x48\x64\x35\x61\xee\xcc\x41\xcf\x45\x86\x2d\xf4\x16\x7d\x77\x77\x86\xa9\x14\xe1\x89\x50\x1c\x77\xcf\xe1\x09\x4a\xcc\x5c\x2f\x08\x63\x08\xde\xcd\x7f\x7f\xff\xa6\xda\xdb\xc3\xa1\x96\x6f\x24\x8e\x07\xa8\xf8\xe1\x70\x55\x63\x46\x43\xf7\xe0\x66\x57\x91\x4d\x8c\xc8\xa9\x32\x32\x1c\xc2\xad\x85\xea\x04\x48\x43\x62\x90\x11\x02\x53\x50\xf7\x22\xb6\x90\x58\x4a\x6e\x98\x29\xcf\x20\x06\xae\x93\xc2\xd9\x09\x52\xa4\xc6\x89\x57\xfb\x1b\xc7\xe9\x1f\x2c\xc3\x81\xd7\xea\x63\xde\x75\x55\x08\x4b\x6d\x60\x20\x91\x40\x40\x0c\xa3\x29\x08\x88\x4a\xb8\x40\xa2\x4a\x69\x35\x05\xf1\xec\xd9\x75\xab\xd8\x1a\x73\x9d\x11\x16\x43\xa1\x38\x2e\x85\x42\x7e\xaa\xd7\x23\xf6\x6d\x85\x7d\x5b\x63\xff\x23\xfe\x0d\x92\x95\x90\xdc\xa0\x3a\xda\xb9\x3d\xb7\xe3\x3e\xa7\xda\x90\x1b\x5f\x6a\x0a\xc2\x6c\x70\x7b\x7d\xa6\x22\x96\x30\xa8\x55\x82\xa4\x09\x3c\x10\x2a\x91\x05\x47\x7b\xc6\x40\xed\xba\x77\xdd\x35\x5b\xf7\x8b\xcb\x10\x6b\xe0\x0b\xe1\x85\x41\xb6\x3e\x3b\x3d\xf4\xb5\xaf\x4b\xcc\x80\x71\xfe\x66\x83\x8a\xde\x0b\x4b\xa8\xd0\x0c\xbc\x44\x8a\x64\xed\x3d\x87\x65\xa1\xca\x86\x02\x83\xae\x7b\xb4\x12\xb6\x8a\xcd\x69\x05\xa4\xd3\x54\xe2\xc0\xab\x86\x9c\x77\x4e\x47\x95\xad\x6a\x54\xc5\x95\xa6\xc2\x5d\xf3\x38\x3e\x89\x85\x14\x2a\x9d\x5e\x30\x58\xab\x04\x65\x31\x05\x19\xdb\xbd\x2b\xdb\x57\x3f\x51\xbd\xa2\x10\x83\x2a\xa4\x3c\x87\x3e\x00\x4a\xdb\xfd\xa7\xe9\xcb\x20\x5b\xa1\xb8\xde\x06\x42\x29\x34\xf5\xe1\x33\xf0\xf2\x9d\x37\xbd\x8f\xef\xfa\x5d\xbb\xf2\x6a\x0a\x2a\x1a\x96\x3f\x8b\xfe\x0f\x00\x00\xff\xff\x71\x13\x5a\x22\x26\x0d\x00\x00")
+var _benchdiffTemplateHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x56\xdf\x6f\xe3\x36\x0c\x7e\xef\x5f\xc1\xf3\x50\xe0\x0e\xbb\x24\x6d\xda\x1d\x8a\x34\x09\xb0\x6b\xb7\x61\x0f\xbb\x15\x6b\x6f\xc0\x1e\x65\x8b\xb6\x84\xca\x92\x21\x31\x4d\x33\x43\xfb\xdb\x07\xc9\x8e\xe3\xfc\x6a\x7b\x5b\x1e\x6a\x4b\x24\xbf\x4f\xa4\xf8\xb1\x9e\xbe\xbb\xfd\xfd\xe6\xe1\xaf\xbb\x9f\x40\x50\xa9\xe6\x27\xd3\xf0\x00\xc5\x74\x31\x4b\x50\x27\x61\x03\x19\x9f\x9f\x00\x00\x4c\x4b\x24\x06\x99\x60\xd6\x21\xcd\x92\xaf\x0f\x3f\x0f\xae\x92\xd6\x44\x92\x14\xce\x7f\x31\xf0\x19\x75\x26\x4a\x66\x1f\xe1\xc6\x94\x15\xb3\xd2\x19\x3d\x1d\x35\xe6\xc6\xd5\xd1\x4a\x21\xd0\xaa\xc2\x59\x42\xf8\x4c\xa3\xcc\xb9\x16\x26\xfc\x86\xd6\x18\x82\xba\x5b\x87\x5f\xca\xb2\xc7\xc2\x9a\x85\xe6\x83\xcc\x28\x63\x27\xf0\xdd\xf8\x62\x7c\x76\x79\x7e\xbd\xe5\xd6\xda\x96\x42\x12\x6e\x2c\xfe\x64\x83\xcd\x65\x9e\xdf\x63\x46\xd2\xe8\x1d\x8a\x92\xd9\x42\xea\x41\x6a\x88\x4c\x39\x81\xf3\x4f\xd5\xf3\xab\x10\x0f\x21\xab\x1d\x9c\xdc\x68\x1a\x38\xf9\x37\x4e\xe0\x79\xa0\x98\x2d\xf0\xfa\x25\x9e\xab\x97\x68\x1e\x58\xba\x87\xbf\x94\x9c\xc4\x04\xce\xcf\xce\x4e\xb7\x81\x53\x63\x39\xda\x50\x1f\xc5\x2a\x87\x13\x58\xbf\xbd\x86\x4f\xe2\xe3\xd6\x92\xef\x30\x86\x3b\x1a\x30\x25\x0b\x3d\x01\x85\x39\x6d\xd3\x56\x8c\x73\xa9\x8b\x09\x5c\x56\xcf\x6f\xc9\x86\xc4\xeb\x77\x7b\xfe\xe9\xfc\xf2\xe2\xec\x55\x24\x3b\xd1\x24\x06\x99\x90\x8a\xbf\xc7\x27\xd4\x1f\xf6\xcf\x7e\xa8\x71\xb2\xf1\xd5\x0f\xe3\xc3\xe0\x7a\x51\xa2\x95\xd9\x0b\x05\xb0\xb2\x10\x04\xef\x64\x59\x19\x4b\x4c\xd3\x61\x9c\xa5\xb1\x6e\xf7\xe2\x5a\x7e\x63\x99\x2e\xd0\x22\x3f\x1c\x99\x22\x11\xda\xc3\xa1\x4a\x96\x58\x58\x44\x7d\xe4\xf0\x46\x1f\xe1\xe4\xcc\x3e\x16\x16\x57\xfd\x38\x00\x80\xe9\x28\x6a\x71\x7e\x32\x1d\x35\x2a\x9f\xa6\x86\xaf\x20\x53\xcc\xb9\x59\x12\x74\x18\x06\x00\x97\x4f\x10\xfd\x66\x09\x97\xae\x52\x6c\x35\x81\x5c\xe1\xf3\x75\xfc\x3b\xe0\xd2\x36\x6a\x88\x1d\xb7\x28\xf5\x75\xdb\xe3\xad\x88\xd6\x13\xa2\x07\xd3\xd3\x48\x54\x48\x72\x7c\x70\x70\xf9\xf4\x96\xf8\xcf\xcc\xa1\x92\x1a\x27\x50\xd7\xc3\xf5\xe2\x96\x11\x7a\xff\x66\x8c\x9b\x85\xb5\xa8\x29\x42\xb4\xef\xdf\x84\x70\x7d\x68\x86\x24\xeb\x6a\xd6\xb5\xcc\x61\xf8\x47\xb8\x40\xe7\xa4\xd1\xce\xfb\xd8\x25\x75\x8d\x9a\x7b\x9f\xcc\x7b\xa6\x78\x84\x2d\xd7\xf6\x08\x9b\x33\xb4\xa8\xbd\x61\xd4\x1b\xa1\x0d\x57\x57\x50\xe7\x7d\x67\x9a\x52\xd4\x4e\x2f\x3c\x8a\xa9\x17\xdc\x78\xd9\xed\x8d\x66\x53\xcc\xef\x58\xf6\xc8\x0a\x9c\x8e\x48\xcc\xc3\xba\xe3\xe8\x76\xbe\x6a\x49\xeb\xc5\x9a\xa6\x95\xd5\xe6\x9a\x8e\x3a\xb4\x75\x8f\xf6\x43\x07\xd8\x0b\xb8\x45\x45\xec\x28\x5c\x35\x78\x62\x6a\x81\xfb\x70\xd3\xd1\x6e\x86\x75\x1d\x85\x79\xa4\x6c\x2f\x14\x85\xcf\xeb\x7a\xd8\xd6\xe5\x0b\x2b\x63\xbf\x10\x3f\xea\xf9\x06\x97\x50\xc2\x17\x5c\xf6\xb2\xac\xeb\xca\x4a\x4d\x39\x24\xa7\xc3\x71\x9e\x40\x27\x80\xdf\x90\x4b\xa6\xbd\x87\xf7\x7a\xd6\xd3\xc5\x3d\x2b\x2b\x85\xce\xfb\x0f\xff\x83\xa3\xbd\xa9\x1d\x8a\x76\xf7\xdb\x19\x60\x57\x1f\x1b\x79\x28\x87\x10\x6c\xbf\x96\x95\x35\x4f\x58\xa2\x26\xef\x9b\x39\xd9\x89\x27\x46\x6b\x43\x30\xbc\x97\x85\x96\xb9\xcc\x58\xf0\xfa\xa7\x17\xbe\x5b\x94\x5e\x46\xdf\x0f\xc7\xf9\xe9\x69\x02\xc3\xd8\x4d\xde\x37\x51\xde\xeb\x11\x6b\x19\xfe\x5b\xa1\x2e\x42\xa1\xee\xfe\x0c\x2d\x78\x08\xe2\x50\x13\x46\xb6\x93\x9e\x47\x50\xe7\xc6\xa9\xaf\xfd\x30\xef\x93\xf5\x30\x6a\x47\x0f\x99\x2a\x7e\x53\xec\xe8\xb9\xc9\xd9\x81\xc9\x81\x04\x42\xda\x8d\x5a\xbb\xd0\xee\x23\xf0\x90\xb6\x03\x66\x11\x9c\x30\x4b\x0d\xb9\xb1\xe1\x3b\x4f\x17\xe8\x60\x29\x49\x00\x83\x56\x4a\x90\xa2\x32\xcb\x30\x9f\x7e\x54\x95\x60\xde\x0f\xb7\x88\x7a\x43\x0b\xe2\xfd\xe9\x48\x58\x46\x7e\x48\x57\x50\x1a\x8b\x40\x82\xe9\x00\xf1\x20\x2c\x3a\x61\x14\xf7\xfe\x74\xd8\x4b\xba\x1b\xb7\x6d\x4d\xe2\x65\x1c\xaf\xc1\xfc\x8b\xd9\xa4\xe4\x60\x89\x16\x43\x62\x20\x35\xa4\x86\x04\x10\x3a\x8a\x99\xee\x23\x77\xd5\x6e\x4d\xdd\x23\xfc\x23\x0c\xcf\xe6\xab\xf8\xdf\x01\x00\x31\xa6\x8e\x77\x26\x0b\x00\x00")
+
+func benchdiffTemplateHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_benchdiffTemplateHtml,
+		"benchdiff-template.html",
+	)
+}
+
+func benchdiffTemplateHtml() (*asset, error) {
+	bytes, err := benchdiffTemplateHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "benchdiff-template.html", size: 2854, mode: os.FileMode(420), modTime: time.Unix(1792194366, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _diffTemplateHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x57\x4b\x6f\xe3\x36\x10\xbe\xe7\x57\x70\x55\x04\xd8\x45\xeb\x57\x92\x2e\x02\x47\x11\xd0\x26\xdd\xa2\x97\x34\xd8\x4d\x0f\x3d\xd2\xe2\x48\x22\x42\x91\x02\x39\x4e\xe2\x0a\xfa\xef\x85\x28\x4a\xb2\x1e\x7e\xa4\xeb\x43\x7d\x88\x44\x0e\x67\xe6\xe3\x70\xbe\x4f\x8c\xff\xe1\xfe\xcf\xbb\xa7\xbf\x1f\x7f\x23\x09\xa6\x22\x38\xf3\xcb\x07\x11\x54\xc6\xb7\x1e\x48\xaf\x9c\x00\xca\x82\x33\x42\x08\xf1\x53\x40\x4a\xc2\x84\x6a\x03\x78\xeb\xfd\xf5\xf4\x65\x72\xed\x39\x13\x72\x14\x10\xfc\xae\xc8\x13\x18\x24\xf7\x3c\x8a\xfc\x59\x35\x57\xd9\x0d\x6e\x04\x10\xdc\x64\x70\xeb\x21\xbc\xe1\x2c\x34\xc6\xf9\x96\xbf\xa9\x56\x0a\x49\xde\x8c\xcb\xdf\x8a\x86\xcf\xb1\x56\x6b\xc9\x26\xa1\x12\x4a\x2f\xc9\x0f\x17\x97\x17\xf3\xab\xc5\x4d\x67\x99\xb3\xbd\x26\x1c\xa1\xb5\x14\x67\x6d\x6c\xc6\xa3\xe8\x1b\x84\xc8\x95\xec\xa5\x48\xa9\x8e\xb9\x9c\xac\x14\xa2\x4a\x97\x64\xf1\x39\x7b\x3b\x18\xe2\xa9\xdc\x55\x2f\x4e\xa4\x24\x4e\x0c\xff\x07\x96\xe4\x6d\x22\xa8\x8e\xe1\x66\x5f\x9e\xeb\x7d\x69\x9e\xe8\x6a\x10\xff\x95\x33\x4c\x96\x64\x31\x9f\x9f\x77\x03\xaf\x94\x66\xa0\xcb\xfa\x08\x9a\x19\x58\x92\xfa\xed\x50\x7c\x4c\x7e\xea\x0c\x59\x2f\x63\x79\x46\x13\x2a\x78\x2c\x97\x44\x40\x84\xdd\xb4\x19\x65\x8c\xcb\x78\x49\xae\xb2\xb7\x63\x76\x83\xc9\xe1\xb3\x5d\x7c\x5e\x5c\x5d\xce\x0f\x46\xd2\x4b\x89\xc9\x24\x4c\xb8\x60\x1f\xe1\x05\xe4\xa7\x21\xf6\xb1\xc6\x09\x2f\xae\x7f\xbe\x18\x0f\x2e\xd7\x29\x68\x1e\xee\x29\x80\xe6\x71\x82\xe4\x03\x4f\x33\xa5\x91\x4a\x1c\x8f\xf3\xaa\xb4\xe9\x1f\x9c\xcb\xaf\x34\x95\x31\x68\x60\xe3\x9e\x2b\x40\x04\x3d\xee\x2a\x78\x0a\xb1\x06\x90\x3b\xc0\x2b\xb9\x23\x27\xa3\xfa\x39\xd6\xb0\xd9\xf6\x23\x84\x10\x7f\x66\xb9\x18\x9c\xf9\xb3\x8a\xda\xfe\x4a\xb1\x0d\x09\x05\x35\xe6\xd6\x2b\x79\x58\xb2\x9e\xf1\x17\x62\xd7\xdd\x7a\x8c\x9b\x4c\xd0\xcd\x92\x44\x02\xde\x6e\xec\xdf\x09\xe3\xba\x62\x83\xed\xb8\x75\x2a\x6f\x5c\x8f\x3b\x12\xd5\xb2\xb0\x15\x66\x8b\x23\x96\x21\x5e\x4f\x2d\x18\x7f\x39\xc6\xe9\x57\x6a\x40\x70\x09\x4b\x92\xe7\xd3\x7a\x70\x4f\x11\x8a\xe2\xc8\x18\x37\x63\xb4\xf7\x82\xbb\xb5\xd6\x20\xd1\xc6\x75\xef\x9d\xb0\x36\x6e\x9e\x33\x88\xb8\x04\xe2\x21\x18\x2c\x81\x1b\xaf\x28\x9c\x89\x47\x64\xea\x06\x3e\xda\x6e\x75\x45\x6d\xda\x77\x4b\xf1\x7c\xd4\x81\x8f\x49\xf0\x48\xc3\x67\x1a\x83\x3f\xc3\xc4\x8e\xcb\x8a\x34\x83\x7a\x7f\xcd\x84\x03\x56\x8d\x67\xa8\xdb\x78\x79\x6e\x7b\xac\x41\xd0\xe6\x60\x41\x9e\x4f\x5d\x9a\x07\x9a\xda\x1d\x21\xab\x0d\xc3\x99\x3a\xe9\x37\xa4\xb8\x36\x5d\x9b\xcb\xdf\x35\xf5\x70\x80\x64\x75\x15\x66\xb6\x0c\x81\xab\x0f\x08\x03\x45\xd1\x9e\x8f\xab\x4e\xd9\xc2\x5e\xf0\xa0\x24\xb8\x4a\xf7\xc3\xd4\xef\x03\xcf\x2d\x55\xde\xae\xec\xf8\x8a\x4a\xb7\x2d\x49\xbd\xe0\x01\x5e\xc5\x86\x44\x94\x0b\x2e\x63\x52\x9e\xa5\x21\x1f\xf3\x5c\x80\x24\x53\x6b\xfb\x52\x99\x8a\xe2\xd3\x16\xa8\x0a\x0c\x42\x9a\x09\x8a\x9d\x1e\xe8\x7b\xb9\xed\x77\xfb\xf1\xbf\xa3\xae\x04\xa2\x86\x9d\x51\x63\x76\xc0\x7e\xac\x4c\xef\x84\xdd\x78\x9d\x16\xb6\x17\xfc\xc2\x18\xb0\x1e\x4e\x3b\x77\x3c\x40\xb7\xfc\xd4\xc8\xbe\x42\xaa\x5e\x06\xd8\xdc\xec\xf1\xe8\x1a\x87\x53\xe3\xbb\x53\x2f\xa0\x69\x0c\xe5\x75\x4b\xc6\xd0\x42\xac\x0d\xf7\x20\x90\x9a\x31\xa4\x3c\x1a\xae\x6a\xb3\x1e\x14\xa6\x3d\xe2\xd4\x30\xb6\xfa\x62\x7a\x03\x7d\x1a\x2c\xe8\xe8\xd5\x88\xdd\xe2\x1b\x51\xb3\x8e\xa2\xed\xdc\x4c\x0d\xb5\x33\x51\x4d\xee\x10\xbd\xb1\x95\x03\x50\x79\x9e\x69\x2e\x31\x22\xde\xf9\x74\x11\x9d\x9f\x7b\xa4\xd1\xc4\xef\x0c\xe3\xca\x71\x7c\x94\xea\x38\x05\x92\xa9\xdd\x3c\x99\x4f\xe7\x45\x61\x35\xac\x96\xd3\x4a\x1a\x9c\x44\x76\x92\xfe\x58\x67\xb5\xae\x63\x39\xc7\x6a\xde\xca\xee\x40\xc1\x07\x2a\x7e\x94\x92\x0f\x3f\x0a\x27\x23\xc9\xfd\x5a\xd3\x72\x48\x74\x79\x45\x32\x86\x2b\xd9\x12\xa5\x36\x7e\x6d\x6d\xbb\xd8\x32\xba\xf4\x34\x94\xe9\x7c\xcf\x4f\xcf\x9f\x3f\x64\xa8\x81\x1a\x38\x44\xa1\xfd\x3b\x3c\x0d\x8f\x7a\xb7\x89\x77\x73\xe4\xd2\x2a\xea\xf7\x32\xad\x8a\xf2\x7e\xa2\xb9\x8b\x41\xd5\x10\x2d\x88\x31\x3e\xd5\x45\x2f\x8a\x9a\x0d\x72\x46\x5d\x8b\xff\xbf\x48\xd6\x3c\xca\x2b\x7e\xf9\xac\xfe\xc9\xff\x77\x00\x31\x6f\x77\x9d\xf5\x0f\x00\x00")
+
+func diffTemplateHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_diffTemplateHtml,
+		"diff-template.html",
+	)
+}
+
+func diffTemplateHtml() (*asset, error) {
+	bytes, err := diffTemplateHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "diff-template.html", size: 4085, mode: os.FileMode(420), modTime: time.Unix(1792194066, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _historyTemplateHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x56\x41\x6f\xe2\x3a\x10\xbe\xf3\x2b\xa6\x79\xaa\xd4\x4a\x2f\x04\x68\x5f\x55\x85\x90\x0b\x7d\x7d\xef\xb6\xd5\x96\x3d\xec\xd1\xc4\x26\xb1\xea\xd8\x91\x3d\xa1\xb0\x11\xff\x7d\xe5\x24\xb4\x49\x08\x15\x54\x5b\x1f\x08\xd8\x33\xdf\x37\xf3\x65\x3e\x8b\xe0\xe2\xe1\xdb\x7c\xf1\xf3\xe9\x5f\x48\x30\x15\xe1\x20\xb0\x0f\x10\x44\xc6\x33\x87\x49\xc7\x6e\x30\x42\xc3\x01\x00\x40\x90\x32\x24\x10\x25\x44\x1b\x86\x33\xe7\xc7\xe2\xd1\xbd\x77\xea\x23\xe4\x28\x58\xf8\x9f\x82\x05\x33\x08\xff\x73\x83\x4a\x6f\x03\xaf\xda\xae\x42\x0c\x6e\x05\x03\xdc\x66\x6c\xe6\x20\xdb\xa0\x17\x19\x53\xa7\xdb\x35\xd4\x4a\x21\x14\x6f\xbf\xed\x5a\x92\xe8\x25\xd6\x2a\x97\xd4\x8d\x94\x50\xda\x87\xbf\x26\x37\x93\xd1\xed\x78\xda\x0a\xab\xcf\x5e\x13\x8e\xec\xfd\x64\x37\x78\xc7\x4e\xaa\x82\x9e\x59\x84\x5c\xc9\x0e\x4b\x4a\x74\xcc\xa5\xbb\x54\x88\x2a\xf5\x61\x72\x9b\x6d\x4e\x41\x59\xd8\xde\x3a\x50\x2b\x25\xd1\x35\xfc\x17\xf3\x61\xe3\x0a\xa2\x63\x36\xfd\x88\xea\xfe\x18\x93\x15\xf9\x04\x31\xc6\x77\xe3\xdb\x9b\x51\x9b\x62\xa9\x34\x65\xda\xd5\x84\xf2\xdc\xf8\xd0\x6a\xc6\x2e\xb5\x66\x7a\x25\xd4\xab\x0f\x6b\x6e\xf8\x52\x7c\xac\xd8\x82\x2c\x0f\x9a\x7c\xe5\x14\x13\x1f\xc6\xa3\xd1\x65\x2f\x75\xa4\x84\x20\x99\x61\x3e\xec\xbf\x9d\x40\x81\xc9\xdf\xdd\x1d\xda\xe1\xb5\x43\xe3\x12\xc1\x63\xe9\x83\x60\x2b\x6c\x93\x67\x84\x52\x2e\xe3\xb2\xe3\xe3\xc2\x76\x38\xcf\x97\xf8\x38\x98\xf6\x25\x26\x6e\x94\x70\x41\xaf\xd8\x9a\xc9\xeb\xc3\x0e\xfa\xe6\x39\x9a\xdc\xff\x33\xe9\xc7\x97\x79\xca\x34\x8f\x3e\x90\x41\xf3\x38\x41\xb8\xe0\x69\xa6\x34\x12\x89\x4d\x1c\x00\x80\xc0\x2b\x5d\x17\x0e\x02\xaf\xf2\x71\xb0\x54\x74\x0b\x91\x20\xc6\xcc\x1c\xeb\x38\x6b\x71\xca\xd7\x50\xc6\xcd\x1c\xca\x4d\x26\xc8\xd6\x87\x95\x60\x9b\x69\xf9\xe9\x52\xae\xab\x89\x2f\x5f\x68\x9e\xca\x69\x3d\xc7\x3e\x8c\xef\xb2\xcd\x74\x7f\x07\x34\x60\x1a\x3e\xa8\x5c\xd0\x9d\x7c\x9b\xe8\x74\xef\x0b\xb8\x2a\x0a\xc1\x24\x0c\xbf\xe7\xd2\xec\x76\xa0\x73\x69\xae\x03\x8f\xf2\x75\x83\xa1\xae\xbd\xed\xc5\xc6\x3d\x72\x3c\xa8\x34\xac\x13\x5a\x46\xd3\x40\xb5\xab\x28\x86\x76\x7b\xae\x72\x89\x73\xeb\xbd\xdd\x5e\xbf\x3f\x4d\xae\x90\x08\x40\xdb\x34\xf2\x94\x1d\x96\xf1\x90\x6b\x62\xe3\xbf\xb4\x8a\x27\x12\xbd\x90\x98\x41\x64\x2f\x03\x12\xf7\x94\x31\xaf\x4f\xbe\xb4\x0c\xfb\x9a\x3b\xd4\x01\x96\x66\x6a\x67\x95\x06\x6b\x00\x57\x81\xba\xbd\x51\x6d\x26\xe1\x03\x41\x16\x78\x98\xf4\x9e\xee\x81\x6b\x67\x59\x25\x8c\x61\xf4\xf4\xf8\x47\xc2\xc5\x39\xf1\xcf\x2f\x3c\xcb\xce\x49\x38\x18\x90\x6e\x62\xe0\x75\x3b\x2f\x0a\x4d\x64\xcc\xf6\xb6\x39\x45\x26\x1a\xda\x51\x23\xc8\x76\xbb\xc0\x43\xda\x1b\x72\x50\x5a\x51\x0c\x2b\xb9\x4a\x07\x9d\x99\x59\x09\xf7\x99\xcc\x5a\xc2\x4f\xa4\x66\x9a\x4b\x5c\x81\x73\x39\xbc\x59\x19\x07\xde\xbc\xf5\xcc\x22\x25\x69\x2f\x58\x9f\xba\x4c\xd2\x86\xaa\x81\x57\x8e\x68\xd8\xb4\xc4\xdb\xc3\xde\xae\xf6\x59\xfd\x99\xfa\x3d\x00\x06\xea\x92\x60\x5d\x09\x00\x00")
+
+func historyTemplateHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_historyTemplateHtml,
+		"history-template.html",
+	)
+}
+
+func historyTemplateHtml() (*asset, error) {
+	bytes, err := historyTemplateHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "history-template.html", size: 2397, mode: os.FileMode(420), modTime: time.Unix(1792194105, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _reportTemplateHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x5a\x6b\x6f\xdb\x38\xd6\xfe\x9e\x5f\x71\x5e\xbd\x53\x8c\x83\x89\xe5\xcb\xa4\x83\x42\xb1\x3d\x98\xa4\xcd\x76\x81\xee\xb6\x68\xb3\x0b\x2c\x3a\xfd\x40\x8b\x47\x36\x1b\x8a\x14\x48\xca\xb1\x9b\xf1\x7f\x5f\x50\x17\x5b\x57\x5b\x4e\xba\xbb\xe3\xa0\x69\x24\x92\xcf\xb9\xf0\xdc\x78\xe8\xc9\xff\xbd\x7e\x7f\x73\xf7\xaf\x0f\x6f\x60\x69\x42\x3e\x3b\x9b\xd8\xff\x80\x13\xb1\x98\x3a\x28\x1c\xfb\x02\x09\x9d\x9d\x01\x00\x4c\x42\x34\x04\xfc\x25\x51\x1a\xcd\xd4\xf9\xc7\xdd\x6d\xff\x95\x93\x0d\x19\x66\x38\xce\xee\xec\xef\xc9\x20\x7d\x48\x07\xb4\xd9\x70\x04\xb3\x89\x70\xea\x18\x5c\x9b\x81\xaf\x75\xb6\xc8\x7e\x5c\x25\xa5\x81\xc7\xdd\xb3\xfd\xcc\x89\x7f\xbf\x50\x32\x16\xb4\xef\x4b\x2e\x95\x07\xff\x3f\xfe\x79\x3c\xbc\x1c\x5d\x95\xa6\x65\x63\x0f\x4b\x66\x70\x3f\xb2\x3d\xdb\x63\xeb\xd8\xf7\x51\xeb\xeb\x1d\xde\x8d\x5d\x72\x94\x1a\x25\xea\x7e\xa1\x10\x45\x33\x6a\x40\x18\x7f\x0a\xa4\x42\xda\xc2\xe6\x3d\x8b\x9e\xc8\xe3\xa6\x85\x45\x4e\xee\x37\x4f\x81\x0c\xc9\x02\x85\x21\xcd\xa8\x4c\xf8\x32\x8c\x38\x1a\x7c\x0a\xb4\x54\x44\x2c\x5a\x36\x6a\x8e\xc2\x5f\x86\x44\xdd\xeb\x0a\x54\x48\xd4\x82\x89\xbe\x91\x91\x07\xa3\x5f\xa2\xf5\xb1\xe5\x89\x01\x56\x30\x02\x29\x4c\x5f\xb3\x6f\xe8\xc1\xba\xcf\x89\x2a\x32\x51\xa0\x31\x97\xc6\xc8\xd0\x83\x57\x1d\xa8\x90\x79\x8d\xca\x03\xa3\x66\xe9\xc1\x68\x38\x7c\x51\x86\x9f\x4b\x45\x51\x59\x4d\x70\x12\x69\xf4\x20\xff\xab\x1b\x15\xb3\xbc\x68\x78\x49\x2b\xd4\xad\x67\xf5\x09\x67\x0b\xe1\x01\xc7\xc0\x94\x59\x88\x08\xa5\x4c\x2c\x3c\xb8\x8c\xd6\xdd\xe5\x33\xcb\xe3\x7e\x39\xfa\x65\x74\xf9\xf3\xb0\xe2\x97\xb1\xd2\x76\x30\x92\x4c\x18\x54\x1d\x89\x29\x4f\x98\x65\xdf\x5f\x32\x4e\x7b\xb8\x42\x71\x5e\x17\xb2\x29\x2e\xf8\xe3\x57\x2f\xc7\xdd\x48\xb8\x22\x0e\x51\x31\xff\x80\xea\x14\x5b\x2c\x4d\x33\x5a\x44\xfc\x7b\xb2\xc0\x1b\xa2\xe8\x3b\xb2\x91\x71\x35\x68\x2d\x14\xa3\x7d\x83\x61\xc4\x89\x41\xcb\x5f\x1c\x0a\xed\xc1\x28\x50\x40\x62\x23\xf7\xbf\xae\xea\xcb\xd2\xd9\xfd\x05\x89\x2a\xe6\x67\x3f\x94\xe9\x88\x93\x8d\x97\x4c\x6d\xe6\xcd\xa0\x36\xcf\x62\xac\x13\xc5\x83\x56\xde\xcc\x7b\x66\xfb\x8a\x50\x16\xeb\xc4\xfc\x0e\xba\xde\xcb\x68\xdd\x6e\xb8\xcd\xa2\x67\xce\xc4\xea\x0e\xd9\xc9\x0c\x0b\xcb\xdf\x22\xb1\xb4\xaa\x28\x8d\x49\xa6\xc4\x5b\x4d\xea\x23\xa1\xc0\x03\x21\x05\x5e\xb5\x1a\x61\xdd\x7f\x65\x6c\x38\x13\xd8\xb4\xb0\x10\xdc\x46\x2f\xbf\xa7\xfa\x0f\x6b\xc9\x23\x81\x41\x55\xd3\x95\x30\x28\x8c\x07\x3f\xfe\x3e\x1c\x8e\xaf\x7f\x6c\x06\x23\xbe\x61\x2b\x3c\x0c\xe0\xfc\x3e\x1e\x8f\xc6\x4e\x57\x6e\x6e\xd2\x75\xf0\xd8\xbc\x41\x43\x18\xbd\xaa\x8b\xbe\xee\x2f\xd1\xba\xbb\x07\x95\xf8\x25\x57\xa8\x02\x2e\x1f\x3c\x58\x32\x4a\x51\x94\x47\x8d\x22\x42\x33\xc3\xa4\xf0\x0a\x20\x30\x74\xc7\x1a\x90\x68\xec\xcb\xb8\x25\x82\x10\x63\xbd\xd0\xe8\xf6\xf4\x34\xfa\xb9\xca\x66\x3a\x68\x4b\x28\x0f\x98\x21\x9c\xf9\x07\x12\xfe\x1d\x6a\xa3\x4f\x4c\x7e\x99\x7d\xaf\x98\xe4\x68\x8e\xa5\xfd\x77\x64\x8e\xbc\x89\xc0\x43\xa6\xc9\xb9\xe4\xf4\x18\xc8\x33\xb8\x3c\x56\x46\xe8\x65\x12\x04\x5b\x98\xcc\x74\x3c\x6e\x0d\x32\x43\xb8\x3c\xd5\x83\x3a\xa4\xc4\x52\x4a\x8a\x19\xa7\xb7\x84\x71\xa4\xcf\xd0\x65\x82\xf2\x3e\x36\x51\x2d\xd6\x77\x8e\xa7\x27\x47\xea\x3c\x74\x8d\xa2\x35\x68\xc9\x19\x3d\x5c\xd2\x16\x59\x8c\x54\x63\x45\x16\x90\x90\xf1\x8d\x07\xa1\x14\x52\x47\xc4\xc7\xab\xee\x5b\x96\x84\xe3\x7e\xb2\xca\xb3\xf8\x6d\x0e\x5c\xcf\x6d\xa9\x86\x12\x01\x61\x68\x7f\x8e\x6e\xd4\x87\x34\xf1\x3f\xd1\x64\x53\x73\x6d\xd5\xd3\x3d\x13\x7f\x6a\x83\x0d\xe2\x6f\xdf\x5e\xa3\x21\x8c\xeb\xa6\xb2\x22\xad\x26\x3c\x18\xc1\x00\xfa\xa3\xf6\x0d\x6c\x4d\xdf\x45\x7c\xd2\x9c\x7a\xb9\x75\x87\x39\x8f\x5b\x7c\x1e\xd7\xc4\xc6\x95\xd7\x2c\x08\x4e\xe5\x30\x99\xa0\xac\x9d\x8c\x8f\x63\x9b\x86\x9a\xbf\x43\x69\x7f\xb0\x1e\x48\x30\xfb\x3c\xa9\xda\x3c\x08\xd8\x1a\x69\x07\x46\x96\x27\xd5\xfe\xad\xe9\xa5\x0d\xff\xbf\xe0\xae\xfd\x07\x45\xa2\x66\xbf\xac\xe4\xe2\x90\x89\x5d\x9a\x1e\x61\x78\x94\xfb\x8f\x18\xca\x15\x76\x38\x38\xfc\x32\x1f\xd1\xd1\x71\x6d\xff\x46\x69\x17\xb4\x11\x7d\x39\x1f\x93\xb6\x72\x65\x85\x8a\x2c\xf0\x5a\x21\xb9\xa7\xf2\x41\x9c\x18\xb3\xf3\x3a\xb6\x1c\xcb\x0e\x53\xd0\x71\x18\x12\xb5\xe9\x5a\x11\x77\xb6\x93\x3a\xa5\x67\xb8\xc5\x53\x29\xd2\xb6\x3a\x6f\x74\xe8\x74\x5b\x07\x7a\xde\x79\x30\xc7\xbb\x65\x4d\xe7\xf1\x03\x99\xbc\xc0\x72\xda\xd7\xb8\xec\xce\xf2\xd3\x42\xe4\x8e\xd5\x58\xf8\xb6\x70\x05\x43\xbd\x80\x29\x6d\xd2\xe3\x76\xb3\x3a\xfb\x36\x94\x78\x30\xbe\x6c\x2c\x49\x0f\x44\x83\x26\xca\xff\x64\x92\x13\x4b\x5a\x1f\x36\xfe\x72\x8b\xe7\x7b\x57\x2c\x0d\xb9\xb8\x7b\xa6\x6e\x10\x25\xe6\x8d\xd2\x1c\x2d\x2e\x6a\x50\x27\xd6\x82\xdd\x99\xb6\x9d\xc5\x8f\x48\xb4\x14\xdf\x25\x37\x9e\x74\x22\x21\x8c\xc7\x0a\xdf\x49\xbf\x71\xe3\x9f\x9f\x9c\x2b\x04\x0e\x1b\x56\x7b\x01\x52\x46\x49\xbc\xf9\x89\xd9\xef\x78\xe1\x5e\x65\xf9\x7f\x9b\x6a\xc7\x99\x95\xb6\xf7\x55\x6d\x5f\xa9\xf1\x9c\x71\x74\xf7\x8e\x27\xad\x02\xf6\x7f\x2a\x5b\x15\x48\xfc\xc9\xce\x20\xfb\xde\xc3\xe5\x70\xd8\xdc\x95\x69\x8c\x22\xcf\x8c\x88\x1d\xfb\xb7\xd9\x18\x0e\xed\x4f\xbb\x6a\x3f\x19\x62\xf4\xfb\x15\xaa\x15\xc3\x87\xee\x4d\xc7\xa6\x7f\x9d\x7b\x90\xe5\x8e\xac\xd6\x48\x9f\xd1\x4e\x38\x72\xc7\xf3\x2c\xec\x83\x21\x39\x7a\x16\xf4\xe1\xab\x1f\x23\x0d\xe1\x37\x59\x86\x39\x81\xc0\x36\xf9\x6b\x32\x48\x42\xfb\xec\x6c\x32\x48\xef\xfd\x26\x73\x49\x37\xe0\x73\xa2\xf5\xd4\xb1\x77\x75\xf6\x4a\x90\xb2\x15\x24\xf3\xa6\xce\x6e\x9f\x02\x8e\xeb\xab\xe4\x77\x9f\x32\x85\x7e\xda\x21\x4b\x77\xfe\x6a\x67\xd3\x49\xac\x81\x5d\x45\x3f\x1c\xae\x96\xf9\x0d\x62\x01\xb4\xc0\x67\xc2\xa5\x33\xfb\x8b\x04\xab\x31\xf8\x88\x91\x54\x66\x32\xa0\x6c\xd5\x65\x59\xb2\xe6\x35\x31\xe8\xc1\xe3\xa3\x6b\x9f\xec\xc3\x76\x5b\x05\xc8\xe4\xab\x59\x75\xe1\xa2\x72\x12\xe5\x64\x8a\x77\x52\xc3\x2b\x27\x5f\x5c\x30\x47\x67\xf6\x21\x79\x00\x0b\xa8\x13\xda\x1f\xf6\xa3\x96\x7c\x74\x02\x70\xc1\x16\x9d\x59\xda\x93\x28\x00\xdf\xee\x47\x2b\xc0\x8f\x8f\x2c\x00\xf7\xae\x68\x0f\xdb\xed\x51\x6a\x25\xfb\x71\x66\xc9\x72\xc8\x2b\x96\x54\x8d\x15\xc4\x41\x34\x7b\x7c\x44\x41\xb7\xdb\xee\x32\x15\xbd\xc0\x99\x7d\x4a\x9f\x0a\x52\x7d\x2a\x8c\x9f\xac\xaf\x5d\x2f\xd4\x99\xdd\xda\xbf\x8b\xda\xda\x8d\x9d\x8a\x5a\x69\x60\x3a\xb3\xbf\xee\x5e\x14\xf0\xf7\x2f\x4f\xc5\x6f\xe8\x38\x39\xb3\x6b\xfb\x12\x52\x03\x80\xec\x06\x2a\x25\x74\xbd\x9f\xde\x4a\xa9\xc1\xdd\xa1\x42\x3d\xdb\x5f\x2b\x00\x18\x16\x16\x36\xd8\x4a\x79\xc7\xc2\xa2\x1c\x05\xaf\x49\x6d\xeb\xa6\x56\x13\x6f\xb7\x35\xa7\xaa\x17\xce\x45\xaf\xb2\x88\xb9\xbc\x30\x47\x2e\x1f\xc0\x2c\x91\xa9\x9d\xc9\x41\xc0\xa5\x8d\x7a\x8f\x8f\x1c\x45\x33\xc9\x02\x5f\x09\x66\xcc\xf7\x0f\x29\xb3\x49\x95\x7c\x80\xdf\xdd\x52\xce\x66\x89\xb3\x26\x0c\xfd\x9d\x58\xf1\x2d\xe9\x48\x31\x61\x02\x70\x5e\xb8\xa3\xe0\xc5\x0b\x67\x8f\xb4\xdd\x5e\xa4\x0c\x36\x4d\xba\xb5\x03\x96\x3d\xce\xaa\x0c\x55\xdc\x65\x90\xb3\x5c\x52\xf1\x7e\x52\x2e\xc1\x0f\x4c\x50\x5c\x5f\xc0\x0f\xc8\x31\x44\x61\xc0\x9b\x82\xfb\xf6\xee\x6f\xef\xde\xa4\xcf\x7a\x37\x3f\x9f\xb1\xdd\x96\xc0\x32\xfc\xc9\xc0\x86\xf5\xd9\xd9\x44\xfb\x8a\x45\x26\x25\x38\x18\xc0\x57\x0d\xe9\x1b\x30\x12\x7c\x85\xc4\x20\x10\x91\x9f\xdf\xed\x51\x3f\x99\xb9\x22\x2a\x79\x07\x53\xa0\xd2\x8f\x2d\x1d\x77\x81\x26\x67\xe2\x7a\x73\x63\xb7\xde\xaa\xaf\xe7\x14\x6e\x6b\x9c\xf3\x34\xdd\x04\x52\x41\x8f\xa3\x01\x06\x53\x18\x5e\x01\x83\x49\x02\xe7\x72\x14\x0b\xb3\xbc\x02\xf6\xd3\x4f\xe7\x85\xd4\x95\x93\xab\x5c\xd4\x4d\x21\x16\x14\x03\x26\x90\xee\xf3\xdf\x0e\xfb\x6b\x8a\xfd\x35\xc3\xfe\xcc\xbe\xb8\xc9\x69\x57\xa1\xd8\xd1\xf9\x5a\xa6\x63\x3f\x76\x69\xae\xdc\x69\x7d\x25\x33\x18\xf6\xbe\x9e\x97\x96\xb0\x00\x7a\xd9\x12\xd7\xcf\x05\xb7\x17\x1e\x3c\xa6\xa8\x7b\x4e\x9d\x75\xe7\xbc\x4a\x36\x4b\xef\x75\x11\x33\xe0\xda\xe4\xb9\xed\x09\x94\xde\x6e\x9b\xca\x81\x3a\xa6\x4b\x28\x7d\xb3\x42\x61\xde\x31\x6d\x50\xa0\xea\x39\x3e\x67\xfe\xbd\x73\x01\x41\xde\x1f\xe8\x55\xd9\x33\x4b\xa6\x53\xd9\xec\x2a\xd7\xc8\xc5\x82\x63\xcf\x49\xaf\xf2\x9c\xb2\x3a\xd2\xdd\x4a\x2f\xe4\xa6\xe9\x4a\x81\xeb\xdc\x38\x3e\xb1\x39\x67\x62\x71\x55\xd3\x60\xb6\xc4\x4d\x62\x97\x1b\x92\xf5\xdb\xa4\x48\x68\x56\x54\xe3\x54\x98\x82\x88\x39\x2f\x43\x6f\x01\xb9\xc6\x06\x90\xc1\x00\x84\x4c\x7b\xae\x59\x3d\x02\x5a\x82\x59\x12\x03\xb8\x8e\x88\xa0\x48\x81\xcb\x05\x44\x44\xa0\x06\x22\x28\x08\xd4\x06\x29\xf8\x44\x51\x0d\x44\x21\x08\x69\xc0\xe7\x49\xa2\x3a\x81\x47\xc7\x5e\xe2\x3a\x57\x6d\x7b\x97\xf9\xc8\xf6\xac\xd1\x27\xb5\x54\xc6\x06\x48\x28\x7c\x63\x26\x6d\xc1\xcd\x37\x40\xb2\x72\xeb\x02\x92\x1d\xb5\x06\x64\xa7\x6a\x12\x62\x36\x02\x64\x41\x98\x00\x85\x2b\x54\x1a\x75\x32\x9c\x1c\x1a\x52\xbf\xcc\xf7\xdf\x52\xb9\xde\x11\xe8\x2d\x53\xcb\xb9\xd8\xc1\x67\x8d\xb3\xaa\x8f\x9a\xa4\x56\x9c\x42\x36\xdf\xf5\xb9\xd4\xa8\x4d\xcf\x49\x38\x74\xce\x5d\x73\x2d\x29\x43\xfd\x79\xf8\xa5\xb4\x4e\xc9\x07\x0d\x53\xf8\x4d\x29\xb2\x71\x03\x25\xc3\x5e\x82\xe4\xda\xf7\xe7\xa5\x99\x14\xb5\x8f\x22\x73\x8d\x9c\x0c\x25\x86\x68\x34\x6e\x22\x08\x4c\xa7\x53\x70\x48\x3e\xcd\xd9\x2d\xb7\x60\xae\x15\xac\xb7\x37\x73\x72\x01\xf3\xaa\x81\x59\x32\x6b\x98\x02\x71\x7d\xe4\x5c\x7f\x4e\x65\xfe\xe2\xda\xb6\x60\x76\xcf\xec\x1a\xc5\xc2\x5e\xdd\xea\xad\xec\xf3\xd3\x97\x65\x7c\xe7\x5a\x85\x5f\xa1\x17\x11\xa5\xf1\x96\x4b\x62\x7a\xeb\x73\xf8\xe3\x0f\x18\x9e\x43\xbf\xf4\x7a\x93\xbf\xf6\x60\xed\x72\xe9\x13\x8e\x37\x32\x8c\x88\xc2\xde\xa6\x4c\x42\xa1\x89\x95\x28\xaa\xee\x57\xe8\xa7\x34\xbd\xc2\xe6\xa7\xc6\x57\x56\x57\x20\xd5\x1b\xe2\x2f\x0b\x1a\x53\xf2\xa1\x16\x1b\x92\xbd\x22\x51\x84\x82\xde\x24\x5f\xdf\xb1\x93\x9a\x30\x5b\x36\xac\xcc\x9a\xb3\x7f\x72\xc0\xab\x6d\xa5\x4d\x61\x79\xd2\x9a\x0c\x92\xef\x2c\xfe\x7b\x00\x9e\x87\x3c\xcc\xc3\x28\x00\x00")
 
 func reportTemplateHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -92,7 +156,27 @@ func reportTemplateHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "report-template.html", size: 3366, mode: os.FileMode(420), modTime: time.Unix(1653785133, 0)}
+	info := bindataFileInfo{name: "report-template.html", size: 10435, mode: os.FileMode(420), modTime: time.Unix(1792195026, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _sourceTemplateHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x56\x4d\x93\xa3\x36\x10\xbd\xcf\xaf\xe8\x68\x6b\xaa\x92\x03\xd8\x78\x3e\xca\x85\x31\x97\x4d\x36\x97\xa9\xcd\x56\x76\x72\xc8\x51\xa0\x06\xab\x2c\x24\x4a\x12\xb3\x76\x54\xfc\xf7\x94\x00\x33\x86\x90\x99\xe1\x30\x20\x75\xf7\xeb\xa7\xee\xa7\x1e\x27\x3f\xfd\xfa\xc7\xe7\xe7\xbf\xbf\xfd\x06\x07\x5b\x89\xf4\x26\xf1\x2f\x10\x54\x96\x7b\x82\x92\xf8\x0d\xa4\x2c\xbd\x01\x00\x48\x2a\xb4\x14\xf2\x03\xd5\x06\xed\x9e\xfc\xf5\xfc\x25\xd8\x92\xc1\x64\xb9\x15\x98\x3a\x17\x7e\xe1\x02\xbf\xd2\x0a\xdb\x36\x59\xf5\x9b\xbd\x83\xb1\x67\x81\x60\xcf\x35\xee\x89\xc5\x93\x5d\xe5\xc6\x0c\xc1\xfe\x09\xb5\x52\x16\xdc\xb8\xf6\x4f\x46\xf3\x63\xa9\x55\x23\x59\x90\x2b\xa1\x74\x0c\x9f\x36\x77\x9b\xf5\x7d\xb4\x9b\xb8\x0d\xb6\x1f\x07\x6e\xf1\xd5\xd2\xde\x8c\x9f\x14\xdc\x52\x80\xe0\xe5\xc1\x66\xa2\x59\x0e\x0a\x05\x96\x28\x19\x98\x9a\xca\x59\x7c\x4d\x19\xe3\xb2\x8c\x61\x0d\xf7\xf5\x69\x4a\xa6\xa2\xba\xe4\x32\xd0\x1e\x3b\x86\xed\xdc\x9c\x29\xcd\x50\x07\x9a\x32\xde\x98\x78\x1a\x7e\x9d\xdc\xa8\x46\xe7\x38\xcb\xcb\xb8\xa9\x05\x3d\xc7\x50\x6a\xce\xa6\xb8\x7e\x27\xb0\x58\xd5\x82\x5a\xf4\xd5\x6a\x2a\x69\x62\xa0\x8d\x55\x10\x15\x7a\xf7\x5e\x5d\xa3\xc7\xe8\xfe\x6e\xfd\x71\xae\xfe\x51\x2f\xa8\x0b\xa1\x7e\xf4\x69\xde\x3c\x47\xad\xe7\x67\x29\x94\xb4\x41\x41\x2b\x2e\xce\x31\x54\x4a\x2a\x53\xd3\x1c\x77\xff\xf5\x31\xfc\x1f\x8c\x21\xba\x9b\x67\x17\x5c\x62\x70\xc0\xbe\xce\xd1\x76\xb9\x0f\x31\xac\x77\xcb\xad\xdb\xfe\x5f\xe5\x3d\xee\xd7\xa6\xca\x50\x9b\x19\x65\xaf\xda\x80\x0a\x5e\xca\x18\xba\xfe\x2e\xca\x90\x51\x7d\x2c\x35\x9e\x97\x6b\x39\xd0\xad\x4f\x60\x94\xe0\x0c\x3e\x6d\xf2\xcd\xf6\x61\xf3\x3e\x95\x05\x19\x8e\x72\xc8\x84\xca\x8f\xbb\x0f\x49\xf4\x1a\x3e\xf7\x0d\x44\xf6\xfe\xa5\x8b\xd8\x43\xb6\xa1\xcb\x20\x8d\xfc\x30\xcc\x63\x16\xb1\x88\x2d\xc3\xd4\x54\x5b\x4e\xc5\x47\x40\x1e\xe8\x14\x04\x00\x20\x59\x75\xe3\x25\xbd\x49\x56\xfd\xb8\x4a\x32\xc5\xce\x90\x0b\x6a\xcc\x9e\xf8\xd1\xe2\x27\x19\xe3\x2f\xd0\xf9\xed\xc9\x58\xbb\x42\xe0\x69\xd7\xfd\x0d\x18\xd7\x98\x5b\xae\x64\x0c\xfd\x05\xda\x8d\x2a\x8a\x1e\xeb\xd3\xee\x32\xea\xae\x60\xae\x14\x2a\xa8\x2e\x91\xa4\x09\x85\x83\xc6\x62\x4f\x9c\x0b\xff\xc4\x5a\x69\xfb\xc4\xe5\xb1\x6d\x49\xfa\xbb\x82\x67\x34\x16\xfa\xdd\x64\x45\x53\x58\x81\x73\xe1\x37\x9a\x1f\x69\x39\xce\x4c\xc6\x5f\xde\xcc\x73\x0a\xba\x4c\xaf\xdc\xb6\xf5\x09\xd6\x64\x3e\x7a\xdf\x83\x99\x80\x04\x99\xb2\x56\x55\x1d\x16\x49\x3f\xfb\x86\xd2\x12\x63\x70\xae\xd6\x5c\xda\x02\xc8\x6d\x18\x15\xb7\xb7\x04\xc2\x8b\xb1\x6d\xe1\x67\xe7\xfa\x25\xb2\xef\x96\x5a\xac\x50\x5a\xd3\xb6\xa0\x0a\x7f\xac\xc9\x96\x19\x17\xbf\xcc\xa9\x0d\x3d\xea\xa7\x2d\xb9\x30\x9d\xf1\xf2\xf5\xbf\xfa\x67\x91\x74\xb7\x61\x88\x1c\xf4\x47\xd2\xe1\x23\x59\x79\x6b\x3a\xf1\x19\x55\x4a\x52\xa9\x2c\xbc\xe1\x39\x08\x91\xa4\xc3\x87\x38\x5f\xbc\xbb\x89\x33\x84\x0c\xa2\x5b\x3c\x49\x3f\xf2\xae\xd9\xd6\x1a\xc7\x63\xbe\x5e\x69\xdf\x32\x4d\x65\x89\x10\x3e\x71\x89\xa6\x6d\x7b\x22\x9c\xed\xc9\x93\x73\x61\xef\xd6\xb6\xe4\x12\x7b\x29\xaa\x17\xd3\x95\x7d\xa0\xe4\x1c\x4a\xe6\x57\xb5\xc6\x69\x6e\xef\xfc\xbd\x23\x35\x31\x0f\xf4\xc7\x97\xbf\x31\xdd\x05\xea\x7e\x07\xfc\x3b\x00\x22\xab\x14\x0f\x18\x08\x00\x00")
+
+func sourceTemplateHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_sourceTemplateHtml,
+		"source-template.html",
+	)
+}
+
+func sourceTemplateHtml() (*asset, error) {
+	bytes, err := sourceTemplateHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "source-template.html", size: 2072, mode: os.FileMode(420), modTime: time.Unix(1792194679, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -149,7 +233,11 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"benchdiff-template.html": benchdiffTemplateHtml,
+	"diff-template.html": diffTemplateHtml,
+	"history-template.html": historyTemplateHtml,
 	"report-template.html": reportTemplateHtml,
+	"source-template.html": sourceTemplateHtml,
 }
 
 // AssetDir returns the file names below a certain
@@ -193,7 +281,11 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
+	"benchdiff-template.html": &bintree{benchdiffTemplateHtml, map[string]*bintree{}},
+	"diff-template.html": &bintree{diffTemplateHtml, map[string]*bintree{}},
+	"history-template.html": &bintree{historyTemplateHtml, map[string]*bintree{}},
 	"report-template.html": &bintree{reportTemplateHtml, map[string]*bintree{}},
+	"source-template.html": &bintree{sourceTemplateHtml, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Thatooine/go-test-html-report/assets"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"html/template"
+	"io/ioutil"
+	"math"
+	"sort"
+)
+
+var regressionThreshold float64
+var significanceLevel float64
+
+type BenchmarkComparison struct {
+	BaselineDate string           `json:"baselineDate"`
+	CurrentDate  string           `json:"currentDate"`
+	Threshold    float64          `json:"thresholdPercent"`
+	Alpha        float64          `json:"alpha"`
+	Regressions  int              `json:"regressions"`
+	Benchmarks   []BenchmarkDelta `json:"benchmarks"`
+}
+
+// BenchmarkDelta compares one measurement of a benchmark, Delta is 0 when the baseline median is 0
+type BenchmarkDelta struct {
+	PackageName     string  `json:"package"`
+	Name            string  `json:"name"`
+	Procs           int     `json:"procs"`
+	Unit            string  `json:"unit"`
+	BaselineMedian  float64 `json:"baselineMedian"`
+	CurrentMedian   float64 `json:"currentMedian"`
+	BaselineSamples int     `json:"baselineSamples"`
+	CurrentSamples  int     `json:"currentSamples"`
+	Delta           float64 `json:"deltaPercent"`
+	PValue          float64 `json:"pValue"`
+	Significant     bool    `json:"significant"`
+	Regression      bool    `json:"regression"`
+	Improvement     bool    `json:"improvement"`
+}
+
+// the measurements compared between runs, for all of them lower is better
+var benchmarkUnits = []string{"ns/op", "B/op", "allocs/op"}
+
+func newBenchDiffCommand() *cobra.Command {
+	benchDiffCmd := &cobra.Command{
+		Use:   "benchdiff [baseline] [current]",
+		Short: "benchdiff compares the benchmarks of two test runs",
+		Long: "benchdiff compares the benchmarks of a baseline and a current test run, each given as a go test json log " +
+			"or a report.json. The medians of the runs of each benchmark are compared with a Mann-Whitney U test, " +
+			"run the benchmarks with -count of 5 or more for significant results",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			baseline, err := ReadTestRun(args[0])
+			if err != nil {
+				log.Error().Err(err).Msg("error reading baseline test run")
+				return err
+			}
+
+			current, err := ReadTestRun(args[1])
+			if err != nil {
+				log.Error().Err(err).Msg("error reading current test run")
+				return err
+			}
+
+			benchmarkComparison := CompareBenchmarks(baseline, current, regressionThreshold, significanceLevel)
+
+			err = GenerateBenchDiffReports(benchmarkComparison)
+			if err != nil {
+				return err
+			}
+
+			log.Info().Msgf("Benchmark comparison generated successfully, %d regressions", benchmarkComparison.Regressions)
+			return nil
+		},
+	}
+	benchDiffCmd.Flags().Float64Var(
+		&regressionThreshold,
+		"regression-threshold",
+		5,
+		"set the percentage by which the median of a benchmark must worsen to be reported as a regression",
+	)
+	benchDiffCmd.Flags().Float64Var(
+		&significanceLevel,
+		"alpha",
+		0.05,
+		"set the p-value below which a change of a benchmark is considered significant",
+	)
+
+	return benchDiffCmd
+}
+
+// CompareBenchmarks compares the benchmarks run in both test runs. Benchmarks are matched by package, name and
+// GOMAXPROCS, the runs of a benchmark with -count are the samples of the comparison
+func CompareBenchmarks(baseline, current *ProcessedTestdata, regressionThreshold, significanceLevel float64) *BenchmarkComparison {
+	benchmarkComparison := &BenchmarkComparison{
+		BaselineDate: baseline.TestDate,
+		CurrentDate:  current.TestDate,
+		Threshold:    regressionThreshold,
+		Alpha:        significanceLevel,
+		Benchmarks:   make([]BenchmarkDelta, 0),
+	}
+
+	baselineSamples := benchmarkSamples(baseline.PackageDetailsMap)
+	currentSamples := benchmarkSamples(current.PackageDetailsMap)
+
+	for _, packageName := range sortedPackageNames(current.PackageDetailsMap) {
+		compared := map[string]bool{}
+		for _, benchmark := range current.PackageDetailsMap[packageName].Benchmarks {
+			key := fmt.Sprintf("%s-%s-%d", packageName, benchmark.Name, benchmark.Procs)
+			if compared[key] {
+				continue
+			}
+			compared[key] = true
+
+			for _, unit := range benchmarkUnits {
+				baselineValues := baselineSamples[key][unit]
+				currentValues := currentSamples[key][unit]
+				if len(baselineValues) == 0 || len(currentValues) == 0 {
+					continue
+				}
+
+				benchmarkDelta := BenchmarkDelta{
+					PackageName:     packageName,
+					Name:            benchmark.Name,
+					Procs:           benchmark.Procs,
+					Unit:            unit,
+					BaselineMedian:  median(baselineValues),
+					CurrentMedian:   median(currentValues),
+					BaselineSamples: len(baselineValues),
+					CurrentSamples:  len(currentValues),
+					PValue:          mannWhitneyUTest(baselineValues, currentValues),
+				}
+				if benchmarkDelta.BaselineMedian > 0 {
+					benchmarkDelta.Delta = (benchmarkDelta.CurrentMedian - benchmarkDelta.BaselineMedian) / benchmarkDelta.BaselineMedian * 100
+				}
+				benchmarkDelta.Significant = benchmarkDelta.PValue < significanceLevel
+				if benchmarkDelta.Significant && benchmarkDelta.CurrentMedian > benchmarkDelta.BaselineMedian {
+					benchmarkDelta.Regression = benchmarkDelta.BaselineMedian == 0 || benchmarkDelta.Delta > regressionThreshold
+				} else if benchmarkDelta.Significant && benchmarkDelta.CurrentMedian < benchmarkDelta.BaselineMedian {
+					benchmarkDelta.Improvement = -benchmarkDelta.Delta > regressionThreshold
+				}
+				if benchmarkDelta.Regression {
+					benchmarkComparison.Regressions = benchmarkComparison.Regressions + 1
+				}
+				benchmarkComparison.Benchmarks = append(benchmarkComparison.Benchmarks, benchmarkDelta)
+			}
+		}
+	}
+
+	return benchmarkComparison
+}
+
+// benchmarkSamples collects the measurements of every run of a benchmark by unit
+func benchmarkSamples(packageDetailsMap map[string]PackageDetails) map[string]map[string][]float64 {
+	samples := map[string]map[string][]float64{}
+	for packageName, packageDetails := range packageDetailsMap {
+		for _, benchmark := range packageDetails.Benchmarks {
+			key := fmt.Sprintf("%s-%s-%d", packageName, benchmark.Name, benchmark.Procs)
+			if samples[key] == nil {
+				samples[key] = map[string][]float64{}
+			}
+			samples[key]["ns/op"] = append(samples[key]["ns/op"], benchmark.NsPerOp)
+			if benchmark.BytesPerOp != nil {
+				samples[key]["B/op"] = append(samples[key]["B/op"], *benchmark.BytesPerOp)
+			}
+			if benchmark.AllocsPerOp != nil {
+				samples[key]["allocs/op"] = append(samples[key]["allocs/op"], *benchmark.AllocsPerOp)
+			}
+		}
+	}
+
+	return samples
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	if len(sorted)%2 == 1 {
+		return sorted[len(sorted)/2]
+	}
+
+	return (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+}
+
+// samples up to this size without ties get the exact p-value, like benchstat
+const mannWhitneyExactLimit = 50
+
+// mannWhitneyUTest returns the two-sided p-value of the Mann-Whitney U test of two samples, the test benchstat uses.
+// The p-value is exact for small samples without ties, otherwise it uses the normal approximation with tie correction
+func mannWhitneyUTest(x, y []float64) float64 {
+	type rankedValue struct {
+		value  float64
+		sample int
+	}
+	values := make([]rankedValue, 0, len(x)+len(y))
+	for _, value := range x {
+		values = append(values, rankedValue{value, 0})
+	}
+	for _, value := range y {
+		values = append(values, rankedValue{value, 1})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].value < values[j].value
+	})
+
+	// rank sum of x, tied values share the mean of their ranks
+	rankSum := 0.0
+	tieCorrection := 0.0
+	ties := false
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].value == values[i].value {
+			j = j + 1
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].sample == 0 {
+				rankSum = rankSum + rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieCorrection = tieCorrection + t*t*t - t
+		}
+		i = j
+	}
+
+	n1 := float64(len(x))
+	n2 := float64(len(y))
+	u := rankSum - n1*(n1+1)/2
+
+	if !ties && len(x) <= mannWhitneyExactLimit && len(y) <= mannWhitneyExactLimit {
+		return exactMannWhitneyPValue(len(x), len(y), int(u))
+	}
+
+	n := n1 + n2
+	variance := n1 * n2 / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance == 0 {
+		return 1
+	}
+	z := (math.Abs(u-n1*n2/2) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return 1
+	}
+
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactMannWhitneyPValue returns the two-sided p-value of the statistic u from the exact distribution of U,
+// counting the orderings of n1 and n2 distinct values that give each U
+func exactMannWhitneyPValue(n1, n2, u int) float64 {
+	maxU := n1 * n2
+	// counts[j][k] is the number of orderings of i and j values with U = k, for the i of the current pass,
+	// previous holds the counts of i-1
+	previous := make([][]float64, n2+1)
+	counts := make([][]float64, n2+1)
+	for j := 0; j <= n2; j++ {
+		previous[j] = make([]float64, maxU+1)
+		counts[j] = make([]float64, maxU+1)
+		counts[j][0] = 1
+	}
+	for i := 1; i <= n1; i++ {
+		previous, counts = counts, previous
+		for j := 0; j <= n2; j++ {
+			for k := range counts[j] {
+				counts[j][k] = 0
+			}
+			if j == 0 {
+				counts[j][0] = 1
+				continue
+			}
+			for k := 0; k <= i*j; k++ {
+				// the largest value is either from the first sample, adding j to U, or from the second
+				if k >= j {
+					counts[j][k] = previous[j][k-j]
+				}
+				counts[j][k] = counts[j][k] + counts[j-1][k]
+			}
+		}
+	}
+
+	total := 0.0
+	lower := 0.0
+	upper := 0.0
+	for k := 0; k <= maxU; k++ {
+		total = total + counts[n2][k]
+		if k <= u {
+			lower = lower + counts[n2][k]
+		}
+		if k >= u {
+			upper = upper + counts[n2][k]
+		}
+	}
+
+	return math.Min(1, 2*math.Min(lower, upper)/total)
+}
+
+// GenerateBenchDiffReports writes the benchmark comparison in every format requested with the format flag
+func GenerateBenchDiffReports(benchmarkComparison *BenchmarkComparison) error {
+	for _, format := range reportFormats {
+		switch format {
+		case "html":
+			err := generateBenchDiffHTMLReport(benchmarkComparison)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating benchdiff html")
+				return err
+			}
+		case "json":
+			benchDiffData, err := json.MarshalIndent(benchmarkComparison, "", "  ")
+			if err != nil {
+				log.Error().Err(err).Msg("error marshalling benchdiff json")
+				return err
+			}
+			err = ioutil.WriteFile(reportPath("benchdiff.json"), benchDiffData, 0644)
+			if err != nil {
+				log.Error().Err(err).Msg("error writing benchdiff.json file")
+				return err
+			}
+		case "markdown":
+			err := ioutil.WriteFile(reportPath("benchdiff.md"), []byte(generateBenchDiffMarkdown(benchmarkComparison)), 0644)
+			if err != nil {
+				log.Error().Err(err).Msg("error writing benchdiff.md file")
+				return err
+			}
+		default:
+			err := fmt.Errorf("report format %q is not supported for benchmark comparisons", format)
+			log.Error().Err(err).Msg("error generating benchdiff reports")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateBenchDiffHTMLReport(benchmarkComparison *BenchmarkComparison) error {
+	benchDiffTemplateData, err := assets.Asset("benchdiff-template.html")
+	if err != nil {
+		log.Error().Err(err).Msg("error retrieving benchdiff-template.html")
+		return err
+	}
+
+	benchDiffTemplate, err := template.New("benchdiff-template.html").Parse(string(benchDiffTemplateData))
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing benchdiff-template.html")
+		return err
+	}
+
+	var processedTemplate bytes.Buffer
+	err = benchDiffTemplate.Execute(&processedTemplate, benchmarkComparison)
+	if err != nil {
+		log.Error().Err(err).Msg("error applying benchdiff-template.html")
+		return err
+	}
+
+	err = ioutil.WriteFile(reportPath("benchdiff.html"), processedTemplate.Bytes(), 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error writing benchdiff.html file")
+		return err
+	}
+
+	return nil
+}
+
+func generateBenchDiffMarkdown(benchmarkComparison *BenchmarkComparison) string {
+	var benchDiff bytes.Buffer
+
+	fmt.Fprintf(&benchDiff, "## Go Benchmark Comparison\n\n")
+	fmt.Fprintf(&benchDiff, "**Baseline:** %s | **Current:** %s | **Regressions:** %d\n\n",
+		benchmarkComparison.BaselineDate,
+		benchmarkComparison.CurrentDate,
+		benchmarkComparison.Regressions,
+	)
+
+	if len(benchmarkComparison.Benchmarks) == 0 {
+		fmt.Fprintf(&benchDiff, "No benchmarks were run in both test runs\n")
+		return benchDiff.String()
+	}
+
+	fmt.Fprintf(&benchDiff, "| Package | Benchmark | Unit | Baseline | Current | Delta | p-value | |\n|---|---|---|---:|---:|---:|---:|---|\n")
+	for _, benchmarkDelta := range benchmarkComparison.Benchmarks {
+		delta := "~"
+		if benchmarkDelta.Significant && benchmarkDelta.BaselineMedian > 0 {
+			delta = fmt.Sprintf("%+.2f%%", benchmarkDelta.Delta)
+		} else if benchmarkDelta.Significant {
+			delta = "n/a"
+		}
+		change := ""
+		if benchmarkDelta.Regression {
+			change = "❌ regression"
+		} else if benchmarkDelta.Improvement {
+			change = "✅ improvement"
+		}
+		fmt.Fprintf(&benchDiff, "| `%s` | `%s` | %s | %.2f (n=%d) | %.2f (n=%d) | %s | %.3f | %s |\n",
+			benchmarkDelta.PackageName,
+			benchmarkDelta.Name,
+			benchmarkDelta.Unit,
+			benchmarkDelta.BaselineMedian,
+			benchmarkDelta.BaselineSamples,
+			benchmarkDelta.CurrentMedian,
+			benchmarkDelta.CurrentSamples,
+			delta,
+			benchmarkDelta.PValue,
+			change,
+		)
+	}
+	fmt.Fprintf(&benchDiff, "\nDeltas are shown for changes with a p-value below %.2f, regressions worsen the median by more than %.1f%%\n",
+		benchmarkComparison.Alpha,
+		benchmarkComparison.Threshold,
+	)
+
+	return benchDiff.String()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"html/template"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type BenchmarkResult struct {
+	Name        string             `json:"name"`
+	Procs       int                `json:"procs"`
+	Iterations  int64              `json:"iterations"`
+	NsPerOp     float64            `json:"nsPerOp"`
+	BytesPerOp  *float64           `json:"bytesPerOp,omitempty"`
+	AllocsPerOp *float64           `json:"allocsPerOp,omitempty"`
+	Metrics     map[string]float64 `json:"metrics,omitempty"`
+}
+
+// a benchmark result line, e.g. "BenchmarkEncode/small-8   1000   1234 ns/op   56 B/op   2 allocs/op".
+// The -N suffix is the GOMAXPROCS the benchmark ran with, it is omitted when that is 1
+var benchmarkLine = regexp.MustCompile(`^(Benchmark\S*?)(?:-(\d+))?\s+(\d+)\s+(.+)$`)
+
+// parseBenchmarks collects the benchmark results of every package. go test writes the name of a benchmark and its
+// result in separate output events, which are attributed to the benchmark on its first run only, so the output of a
+// package is put back together into lines before parsing. The test tree takes the benchmarks that finished from the
+// same results, so the benchmarks table and the test counts agree
+func parseBenchmarks(rowData []GoTestJsonRowData) map[string][]BenchmarkResult {
+	benchmarks := map[string][]BenchmarkResult{}
+	pendingOutput := map[string]string{}
+	for _, r := range rowData {
+		if r.Action != "output" {
+			continue
+		}
+
+		output := pendingOutput[r.Package] + r.Output
+		for strings.Contains(output, "\n") {
+			line := output[:strings.Index(output, "\n")]
+			output = output[strings.Index(output, "\n")+1:]
+			if benchmark, ok := parseBenchmarkLine(line); ok {
+				benchmarks[r.Package] = append(benchmarks[r.Package], benchmark)
+			}
+		}
+		pendingOutput[r.Package] = output
+	}
+
+	return benchmarks
+}
+
+// hasBenchmarkResult reports whether a result of a benchmark or one of its sub-benchmarks is among the parsed
+// benchmark results of its package
+func hasBenchmarkResult(benchmarks []BenchmarkResult, benchmarkName string) bool {
+	for _, benchmark := range benchmarks {
+		if benchmark.Name == benchmarkName || strings.HasPrefix(benchmark.Name, benchmarkName+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseBenchmarkLine parses a benchmark result line into its name, iterations and measurements per unit
+func parseBenchmarkLine(line string) (BenchmarkResult, bool) {
+	match := benchmarkLine.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return BenchmarkResult{}, false
+	}
+
+	benchmark := BenchmarkResult{Name: match[1], Procs: 1}
+	if match[2] != "" {
+		benchmark.Procs, _ = strconv.Atoi(match[2])
+	}
+	benchmark.Iterations, _ = strconv.ParseInt(match[3], 10, 64)
+
+	// measurements come in value unit pairs
+	fields := strings.Fields(match[4])
+	if len(fields)%2 != 0 {
+		return BenchmarkResult{}, false
+	}
+	for i := 0; i < len(fields); i = i + 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return BenchmarkResult{}, false
+		}
+		switch unit := fields[i+1]; unit {
+		case "ns/op":
+			benchmark.NsPerOp = value
+		case "B/op":
+			benchmark.BytesPerOp = &value
+		case "allocs/op":
+			benchmark.AllocsPerOp = &value
+		default:
+			if benchmark.Metrics == nil {
+				benchmark.Metrics = map[string]float64{}
+			}
+			benchmark.Metrics[unit] = value
+		}
+	}
+
+	return benchmark, true
+}
+
+// generate a sortable table of the benchmarks of all packages, empty if no benchmarks were run
+func generateBenchmarksHTMLElement(packageDetailsMap map[string]PackageDetails) (string, error) {
+	rows := make([]map[string]interface{}, 0)
+	for _, packageName := range sortedPackageNames(packageDetailsMap) {
+		for _, benchmark := range packageDetailsMap[packageName].Benchmarks {
+			rows = append(rows, map[string]interface{}{
+				"packageName": packageName,
+				"name":        benchmark.Name,
+				"procs":       benchmark.Procs,
+				"iterations":  benchmark.Iterations,
+				"nsPerOp":     formatBenchmarkValue(&benchmark.NsPerOp),
+				"bytesPerOp":  formatBenchmarkValue(benchmark.BytesPerOp),
+				"allocsPerOp": formatBenchmarkValue(benchmark.AllocsPerOp),
+				"metrics":     formatBenchmarkMetrics(benchmark.Metrics),
+			})
+		}
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	benchmarksTemplate, err := template.New("benchmarks").Parse(`
+		<div class="benchmarks">
+			<div class="benchmarksTitle">Benchmarks</div>
+			<table class="benchmarksTable">
+				<thead>
+					<tr>
+						<th onclick="sortBenchmarks(this, 0, false)">Package</th>
+						<th onclick="sortBenchmarks(this, 1, false)">Benchmark</th>
+						<th class="numeric" onclick="sortBenchmarks(this, 2, true)">Procs</th>
+						<th class="numeric" onclick="sortBenchmarks(this, 3, true)">Iterations</th>
+						<th class="numeric" onclick="sortBenchmarks(this, 4, true)">ns/op</th>
+						<th class="numeric" onclick="sortBenchmarks(this, 5, true)">B/op</th>
+						<th class="numeric" onclick="sortBenchmarks(this, 6, true)">allocs/op</th>
+						<th>Metrics</th>
+					</tr>
+				</thead>
+				<tbody>
+					{{range .}}
+					<tr>
+						<td>{{.packageName}}</td>
+						<td>{{.name}}</td>
+						<td class="numeric">{{.procs}}</td>
+						<td class="numeric">{{.iterations}}</td>
+						<td class="numeric">{{.nsPerOp}}</td>
+						<td class="numeric">{{.bytesPerOp}}</td>
+						<td class="numeric">{{.allocsPerOp}}</td>
+						<td>{{.metrics}}</td>
+					</tr>
+					{{end}}
+				</tbody>
+			</table>
+		</div>`)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing benchmarks template")
+		return "", err
+	}
+
+	var processedTemplate bytes.Buffer
+	err = benchmarksTemplate.Execute(&processedTemplate, rows)
+	if err != nil {
+		log.Error().Err(err).Msg("error applying benchmarks template")
+		return "", err
+	}
+
+	return processedTemplate.String(), nil
+}
+
+// formatBenchmarkValue formats a measurement the way go test prints it, "-" if it was not measured
+func formatBenchmarkValue(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// formatBenchmarkMetrics lists the custom metrics reported with b.ReportMetric, ordered by unit
+func formatBenchmarkMetrics(metrics map[string]float64) string {
+	units := make([]string, 0, len(metrics))
+	for unit := range metrics {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+
+	formattedMetrics := make([]string, 0, len(units))
+	for _, unit := range units {
+		value := metrics[unit]
+		formattedMetrics = append(formattedMetrics, fmt.Sprintf("%s %s", formatBenchmarkValue(&value), unit))
+	}
+
+	return strings.Join(formattedMetrics, ", ")
+}
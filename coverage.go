@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var coverProfiles []string
+var sourceDirectory string
+
+// the kinds of a coverage value
+const (
+	CoveragePercent      = "percent"
+	CoverageNoStatements = "no-statements"
+	CoverageUnknown      = "unknown"
+)
+
+// CoverageValue is the coverage of a package. Scope names the packages the percentage was measured over when
+// go test ran with -coverpkg, e.g. "./..."
+type CoverageValue struct {
+	Kind    string  `json:"kind"`
+	Percent float64 `json:"percent,omitempty"`
+	Scope   string  `json:"scope,omitempty"`
+}
+
+// the coverage go test reports for a package, alone or at the end of its result line:
+// "coverage: 63.2% of statements", "coverage: 45.0% of statements in ./..." or "coverage: [no statements]"
+var coverageLine = regexp.MustCompile(`coverage: (?:(\d+(?:\.\d+)?)% of statements(?: in (.+))?|(\[no statements\]))`)
+
+// CoverageBlock is a block of statements of a cover profile, FileName is the import path of its package joined with
+// the name of the file
+type CoverageBlock struct {
+	FileName  string
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	NumStmt   int
+	Count     int
+}
+
+type FileCoverage struct {
+	FileName          string             `json:"fileName"`
+	Statements        int                `json:"statements"`
+	CoveredStatements int                `json:"coveredStatements"`
+	Coverage          float64            `json:"coverage"`
+	Functions         []FunctionCoverage `json:"functions,omitempty"`
+	// the annotated source page linked from the package card, relative to the report
+	SourcePage string `json:"-"`
+
+	sourceFile string
+	blocks     []CoverageBlock
+}
+
+type FunctionCoverage struct {
+	Name              string  `json:"name"`
+	Line              int     `json:"line"`
+	Statements        int     `json:"statements"`
+	CoveredStatements int     `json:"coveredStatements"`
+	Coverage          float64 `json:"coverage"`
+}
+
+// a block of a cover profile, e.g. "example.com/pkg/file.go:3.26,5.2 2 1"
+var coverProfileLine = regexp.MustCompile(`^(.+):(\d+)\.(\d+),(\d+)\.(\d+) (\d+) (\d+)$`)
+
+// ParseCoverageLine parses the coverage of an output line of a package, it reports false for lines without coverage
+func ParseCoverageLine(line string) (CoverageValue, bool) {
+	match := coverageLine.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return CoverageValue{}, false
+	}
+	if match[3] != "" {
+		return CoverageValue{Kind: CoverageNoStatements}, true
+	}
+
+	percent, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return CoverageValue{}, false
+	}
+
+	return CoverageValue{Kind: CoveragePercent, Percent: percent, Scope: strings.TrimSpace(match[2])}, true
+}
+
+// String returns the coverage as shown in the reports, e.g. "63.2%", "45.0% in ./...", "[no statements]" or "-"
+func (coverageValue CoverageValue) String() string {
+	switch coverageValue.Kind {
+	case CoveragePercent:
+		if coverageValue.Scope != "" {
+			return fmt.Sprintf("%.1f%% in %s", coverageValue.Percent, coverageValue.Scope)
+		}
+		return fmt.Sprintf("%.1f%%", coverageValue.Percent)
+	case CoverageNoStatements:
+		return "[no statements]"
+	}
+
+	return "-"
+}
+
+// packageCoveragePercent returns the coverage percentage of a package, reports written before the coverage value
+// was added only hold the display form
+func packageCoveragePercent(packageDetails PackageDetails) (float64, bool) {
+	if packageDetails.CoverageValue.Kind == "" {
+		return parseCoveragePercent(packageDetails.Coverage)
+	}
+
+	return packageDetails.CoverageValue.Percent, packageDetails.CoverageValue.Kind == CoveragePercent
+}
+
+// ReadCoverProfiles reads and merges cover profiles written with -coverprofile in the set, count or atomic mode.
+// A block found in several profiles, e.g. of shards or of -coverpkg runs, is covered if it was covered in any of them
+func ReadCoverProfiles(fileNames []string) ([]CoverageBlock, error) {
+	blockIndexes := map[string]int{}
+	blocks := make([]CoverageBlock, 0)
+	for _, fileName := range fileNames {
+		profileBlocks, err := readCoverProfile(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range profileBlocks {
+			key := fmt.Sprintf("%s:%d.%d,%d.%d", block.FileName, block.StartLine, block.StartCol, block.EndLine, block.EndCol)
+			if i, ok := blockIndexes[key]; ok {
+				blocks[i].Count = blocks[i].Count + block.Count
+				continue
+			}
+			blockIndexes[key] = len(blocks)
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+func readCoverProfile(fileName string) ([]CoverageBlock, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Error().Err(err).Msg("error opening cover profile")
+		return nil, err
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("error closing cover profile")
+		}
+	}()
+
+	blocks := make([]CoverageBlock, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		match := coverProfileLine.FindStringSubmatch(line)
+		if match == nil {
+			err = fmt.Errorf("%s is not a cover profile, unexpected line %q", fileName, line)
+			log.Error().Err(err).Msg("error parsing cover profile")
+			return nil, err
+		}
+		block := CoverageBlock{FileName: match[1]}
+		block.StartLine, _ = strconv.Atoi(match[2])
+		block.StartCol, _ = strconv.Atoi(match[3])
+		block.EndLine, _ = strconv.Atoi(match[4])
+		block.EndCol, _ = strconv.Atoi(match[5])
+		block.NumStmt, _ = strconv.Atoi(match[6])
+		block.Count, _ = strconv.Atoi(match[7])
+		blocks = append(blocks, block)
+	}
+
+	if err = scanner.Err(); err != nil {
+		log.Error().Err(err).Msg("error scanning cover profile")
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// ApplyCoverProfiles replaces the coverage of the tested packages with the statement coverage of the cover profiles,
+// broken down by file and, when the source of a file is found below the source directory, by function
+func ApplyCoverProfiles(processedTestdata *ProcessedTestdata, blocks []CoverageBlock) {
+	fileBlocks := map[string][]CoverageBlock{}
+	for _, block := range blocks {
+		fileBlocks[block.FileName] = append(fileBlocks[block.FileName], block)
+	}
+
+	fileNames := make([]string, 0, len(fileBlocks))
+	for fileName := range fileBlocks {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		packageName := path.Dir(fileName)
+		packageDetails, ok := processedTestdata.PackageDetailsMap[packageName]
+		if !ok {
+			continue
+		}
+
+		fileCoverage := FileCoverage{FileName: fileName, blocks: fileBlocks[fileName]}
+		fileCoverage.Statements, fileCoverage.CoveredStatements = countCoveredStatements(fileBlocks[fileName])
+		fileCoverage.Coverage = coveragePercent(fileCoverage.Statements, fileCoverage.CoveredStatements)
+		if sourceFile, ok := findSourceFile(fileName); ok {
+			fileCoverage.sourceFile = sourceFile
+			fileCoverage.SourcePage = sourcePagePath(fileName)
+			fileCoverage.Functions = functionCoverage(sourceFile, fileBlocks[fileName])
+		}
+
+		packageDetails.Files = append(packageDetails.Files, fileCoverage)
+		packageDetails.Statements = packageDetails.Statements + fileCoverage.Statements
+		packageDetails.CoveredStatements = packageDetails.CoveredStatements + fileCoverage.CoveredStatements
+		packageDetails.CoverageValue = CoverageValue{
+			Kind:    CoveragePercent,
+			Percent: coveragePercent(packageDetails.Statements, packageDetails.CoveredStatements),
+		}
+		packageDetails.Coverage = packageDetails.CoverageValue.String()
+		processedTestdata.PackageDetailsMap[packageName] = packageDetails
+	}
+}
+
+func countCoveredStatements(blocks []CoverageBlock) (int, int) {
+	statements := 0
+	coveredStatements := 0
+	for _, block := range blocks {
+		statements = statements + block.NumStmt
+		if block.Count > 0 {
+			coveredStatements = coveredStatements + block.NumStmt
+		}
+	}
+
+	return statements, coveredStatements
+}
+
+func coveragePercent(statements, coveredStatements int) float64 {
+	if statements == 0 {
+		return 0
+	}
+
+	return float64(coveredStatements) * 100 / float64(statements)
+}
+
+// totalStatementCoverage returns the statement coverage of all packages with cover profile data, or nil without any
+func totalStatementCoverage(packageDetailsMap map[string]PackageDetails) *TotalCoverage {
+	totalCoverage := &TotalCoverage{}
+	for _, packageDetails := range packageDetailsMap {
+		totalCoverage.Statements = totalCoverage.Statements + packageDetails.Statements
+		totalCoverage.CoveredStatements = totalCoverage.CoveredStatements + packageDetails.CoveredStatements
+	}
+	if totalCoverage.Statements == 0 {
+		return nil
+	}
+	totalCoverage.Percent = coveragePercent(totalCoverage.Statements, totalCoverage.CoveredStatements)
+
+	return totalCoverage
+}
+
+// functionCoverage returns the coverage of the functions of a source file, like go tool cover -func
+func functionCoverage(sourceFile string, blocks []CoverageBlock) []FunctionCoverage {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, sourceFile, nil, 0)
+	if err != nil {
+		log.Warn().Err(err).Msgf("error parsing %s, skipping its function coverage", sourceFile)
+		return nil
+	}
+
+	functions := make([]FunctionCoverage, 0)
+	for _, declaration := range file.Decls {
+		function, ok := declaration.(*ast.FuncDecl)
+		if !ok || function.Body == nil {
+			continue
+		}
+		start := fileSet.Position(function.Pos())
+		end := fileSet.Position(function.End())
+
+		functionBlocks := make([]CoverageBlock, 0)
+		for _, block := range blocks {
+			startsInside := block.StartLine > start.Line || (block.StartLine == start.Line && block.StartCol >= start.Column)
+			endsInside := block.EndLine < end.Line || (block.EndLine == end.Line && block.EndCol <= end.Column)
+			if startsInside && endsInside {
+				functionBlocks = append(functionBlocks, block)
+			}
+		}
+
+		functionCoverage := FunctionCoverage{Name: functionName(function), Line: start.Line}
+		functionCoverage.Statements, functionCoverage.CoveredStatements = countCoveredStatements(functionBlocks)
+		functionCoverage.Coverage = coveragePercent(functionCoverage.Statements, functionCoverage.CoveredStatements)
+		functions = append(functions, functionCoverage)
+	}
+
+	return functions
+}
+
+// functionName returns the name of a function, methods are prefixed with their receiver type, e.g. (*Parser).Parse
+func functionName(function *ast.FuncDecl) string {
+	if function.Recv == nil || len(function.Recv.List) == 0 {
+		return function.Name.Name
+	}
+
+	receiverType := function.Recv.List[0].Type
+	// drop the type parameters of a generic receiver
+	if indexExpr, ok := receiverType.(*ast.IndexExpr); ok {
+		receiverType = indexExpr.X
+	}
+	if star, ok := receiverType.(*ast.StarExpr); ok {
+		receiverType = star.X
+		if indexExpr, ok := receiverType.(*ast.IndexExpr); ok {
+			receiverType = indexExpr.X
+		}
+		if ident, ok := receiverType.(*ast.Ident); ok {
+			return fmt.Sprintf("(*%s).%s", ident.Name, function.Name.Name)
+		}
+	}
+	if ident, ok := receiverType.(*ast.Ident); ok {
+		return fmt.Sprintf("%s.%s", ident.Name, function.Name.Name)
+	}
+
+	return function.Name.Name
+}
+
+// findSourceFile maps the file name of a cover profile to the file below the source directory, using the module path
+// of the go.mod found there
+func findSourceFile(fileName string) (string, bool) {
+	if filepath.IsAbs(fileName) {
+		_, err := os.Stat(fileName)
+		return fileName, err == nil
+	}
+
+	modulePath := readModulePath(sourceDirectory)
+	if modulePath == "" || !strings.HasPrefix(fileName, modulePath+"/") {
+		return "", false
+	}
+
+	sourceFile := filepath.Join(sourceDirectory, filepath.FromSlash(strings.TrimPrefix(fileName, modulePath+"/")))
+	if _, err := os.Stat(sourceFile); err != nil {
+		return "", false
+	}
+
+	return sourceFile, true
+}
+
+var modulePaths = map[string]string{}
+
+// readModulePath returns the module path declared by the go.mod of a directory, or "" if there is none
+func readModulePath(directory string) string {
+	if modulePath, ok := modulePaths[directory]; ok {
+		return modulePath
+	}
+
+	modulePath := ""
+	goMod, err := ioutil.ReadFile(filepath.Join(directory, "go.mod"))
+	if err == nil {
+		for _, line := range strings.Split(string(goMod), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "module" {
+				modulePath = strings.Trim(fields[1], `"`)
+				break
+			}
+		}
+	}
+	modulePaths[directory] = modulePath
+
+	return modulePath
+}
+
+// generate the coverage of the files and functions of a package shown on its card
+func generateCoverageBreakdownHTMLElement(packageDetails PackageDetails) (template.HTML, error) {
+	if len(packageDetails.Files) == 0 {
+		return "", nil
+	}
+
+	coverageBreakdownTemplate, err := template.New("coverageBreakdown").Parse(`
+										<details class="coverageBreakdown">
+											<summary>Coverage: {{.coveredStatements}} of {{.statements}} statements in {{len .files}} files</summary>
+											<table>
+												{{range .files}}
+												<tr class="coverageFile">
+													<td>{{if .SourcePage}}<a href="{{.SourcePage}}">{{.FileName}}</a>{{else}}{{.FileName}}{{end}}</td>
+													<td class="numeric">{{.CoveredStatements}}/{{.Statements}}</td>
+													<td class="numeric">{{printf "%.1f%%" .Coverage}}</td>
+												</tr>
+												{{$sourcePage := .SourcePage}}
+												{{range .Functions}}
+												<tr class="coverageFunction">
+													<td><a href="{{$sourcePage}}#L{{.Line}}">{{.Name}}</a>:{{.Line}}</td>
+													<td class="numeric">{{.CoveredStatements}}/{{.Statements}}</td>
+													<td class="numeric">{{printf "%.1f%%" .Coverage}}</td>
+												</tr>
+												{{end}}
+												{{end}}
+											</table>
+										</details>
+									`)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing coverage breakdown template")
+		return "", err
+	}
+
+	var processedCoverageBreakdownTemplate bytes.Buffer
+	err = coverageBreakdownTemplate.Execute(&processedCoverageBreakdownTemplate, map[string]interface{}{
+		"statements":        packageDetails.Statements,
+		"coveredStatements": packageDetails.CoveredStatements,
+		"files":             packageDetails.Files,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error applying coverage breakdown template")
+		return "", err
+	}
+
+	return template.HTML(processedCoverageBreakdownTemplate.String()), nil
+}
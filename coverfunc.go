@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var coverFuncOutputs []string
+
+// TotalCoverage is the statement coverage of the whole test run. Statements and CoveredStatements are left out
+// when the coverage was read from the total line of go tool cover -func output, which is marked NotMergeable
+type TotalCoverage struct {
+	Percent           float64 `json:"percent"`
+	Statements        int     `json:"statements,omitempty"`
+	CoveredStatements int     `json:"coveredStatements,omitempty"`
+	// the statements behind the percentage are unknown, it cannot be combined with the coverage of other runs
+	NotMergeable bool `json:"notMergeable,omitempty"`
+}
+
+func (totalCoverage *TotalCoverage) String() string {
+	return fmt.Sprintf("%.1f%%", totalCoverage.Percent)
+}
+
+// a function of go tool cover -func output, e.g. "example.com/pkg/file.go:5:\tSub\t\t66.7%"
+var coverFuncLine = regexp.MustCompile(`^(.+):(\d+):\s+\S+\s+(\d+(?:\.\d+)?)%$`)
+
+// the last line of go tool cover -func output, e.g. "total:\t\t\t(statements)\t75.0%"
+var coverFuncTotalLine = regexp.MustCompile(`^total:\s+\(statements\)\s+(\d+(?:\.\d+)?)%$`)
+
+// ReadCoverFuncOutputs reads the total coverage of go tool cover -func output from its total line, which go tool
+// cover weighted by the statements of the functions. The output holds no statement counts, so the totals of several
+// outputs cannot be combined, the cover profiles passed with --coverprofile hold them
+func ReadCoverFuncOutputs(fileNames []string) (*TotalCoverage, error) {
+	if len(fileNames) > 1 {
+		err := fmt.Errorf("the total coverage of %d go tool cover -func outputs cannot be combined, their statements are unknown", len(fileNames))
+		log.Error().Err(err).Msg("error reading go tool cover -func outputs, pass their cover profiles with --coverprofile instead")
+		return nil, err
+	}
+
+	total, err := readCoverFuncTotal(fileNames[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TotalCoverage{Percent: total, NotMergeable: true}, nil
+}
+
+// ApplyTotalCoverage sets the total coverage of the run from the statements of the cover profiles, or else from
+// the total coverage of the go tool cover -func output, which is nil without it
+func ApplyTotalCoverage(processedTestdata *ProcessedTestdata, coverFuncCoverage *TotalCoverage) {
+	processedTestdata.TotalCoverage = totalStatementCoverage(processedTestdata.PackageDetailsMap)
+	if processedTestdata.TotalCoverage == nil {
+		processedTestdata.TotalCoverage = coverFuncCoverage
+	}
+}
+
+// readCoverFuncTotal returns the percentage of the total line of go tool cover -func output
+func readCoverFuncTotal(fileName string) (float64, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Error().Err(err).Msgf("error opening go tool cover -func output %s", fileName)
+		return 0, err
+	}
+	defer file.Close()
+
+	total := -1.0
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || coverFuncLine.MatchString(line) {
+			continue
+		}
+
+		match := coverFuncTotalLine.FindStringSubmatch(line)
+		if match == nil {
+			err = fmt.Errorf("%s:%d: unexpected line %q", fileName, lineNumber, line)
+			log.Error().Err(err).Msg("error parsing go tool cover -func output")
+			return 0, err
+		}
+		total, _ = strconv.ParseFloat(match[1], 64)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Error().Err(err).Msgf("error reading go tool cover -func output %s", fileName)
+		return 0, err
+	}
+	if total < 0 {
+		err = fmt.Errorf("%s has no total line", fileName)
+		log.Error().Err(err).Msg("error parsing go tool cover -func output")
+		return 0, err
+	}
+
+	return total, nil
+}
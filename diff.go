@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Thatooine/go-test-html-report/assets"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"html/template"
+	"io/ioutil"
+	"sort"
+)
+
+var durationThreshold float64
+var minDurationIncrease float64
+
+type TestRunDiff struct {
+	BaselineDate        string               `json:"baselineDate"`
+	CurrentDate         string               `json:"currentDate"`
+	NewlyFailing        []TestDiff           `json:"newlyFailing"`
+	NewlyPassing        []TestDiff           `json:"newlyPassing"`
+	Added               []TestDiff           `json:"added"`
+	Removed             []TestDiff           `json:"removed"`
+	CoverageDeltas      []CoverageDelta      `json:"coverageDeltas"`
+	DurationRegressions []DurationRegression `json:"durationRegressions"`
+}
+
+type TestDiff struct {
+	PackageName    string `json:"package"`
+	Name           string `json:"name"`
+	BaselineStatus string `json:"baselineStatus,omitempty"`
+	CurrentStatus  string `json:"currentStatus,omitempty"`
+}
+
+type CoverageDelta struct {
+	PackageName string  `json:"package"`
+	Baseline    float64 `json:"baseline"`
+	Current     float64 `json:"current"`
+	Delta       float64 `json:"delta"`
+}
+
+// DurationRegression is a test that slowed down, Increase is 0 when the test took no measurable time in the baseline
+type DurationRegression struct {
+	PackageName string  `json:"package"`
+	Name        string  `json:"name"`
+	Baseline    float64 `json:"baselineSeconds"`
+	Current     float64 `json:"currentSeconds"`
+	Increase    float64 `json:"increasePercent"`
+}
+
+func newDiffCommand() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff [baseline] [current]",
+		Short: "diff compares two test runs",
+		Long: "diff compares a baseline and a current test run, each given as a go test json log or a report.json, " +
+			"and reports newly failing, newly passing, added and removed tests, coverage deltas and duration regressions",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			baseline, err := ReadTestRun(args[0])
+			if err != nil {
+				log.Error().Err(err).Msg("error reading baseline test run")
+				return err
+			}
+
+			current, err := ReadTestRun(args[1])
+			if err != nil {
+				log.Error().Err(err).Msg("error reading current test run")
+				return err
+			}
+
+			testRunDiff := DiffTestRuns(baseline, current, durationThreshold, minDurationIncrease)
+
+			err = GenerateDiffReports(testRunDiff)
+			if err != nil {
+				return err
+			}
+
+			log.Info().Msgf("Diff report generated successfully")
+			return nil
+		},
+	}
+	diffCmd.Flags().Float64Var(
+		&durationThreshold,
+		"duration-threshold",
+		20,
+		"set the percentage by which a test must slow down to be reported as a duration regression",
+	)
+	diffCmd.Flags().Float64Var(
+		&minDurationIncrease,
+		"min-duration-increase",
+		0.1,
+		"set the seconds by which a test must slow down to be reported as a duration regression",
+	)
+
+	return diffCmd
+}
+
+// DiffTestRuns compares the tests, package coverage and test durations of a baseline and a current test run
+func DiffTestRuns(baseline, current *ProcessedTestdata, durationThreshold, minDurationIncrease float64) *TestRunDiff {
+	testRunDiff := &TestRunDiff{
+		BaselineDate:        baseline.TestDate,
+		CurrentDate:         current.TestDate,
+		NewlyFailing:        make([]TestDiff, 0),
+		NewlyPassing:        make([]TestDiff, 0),
+		Added:               make([]TestDiff, 0),
+		Removed:             make([]TestDiff, 0),
+		CoverageDeltas:      make([]CoverageDelta, 0),
+		DurationRegressions: make([]DurationRegression, 0),
+	}
+
+	baselineTests := flattenTests(map[string]*TestDetails{}, baseline.TestSummary)
+	currentTests := flattenTests(map[string]*TestDetails{}, current.TestSummary)
+
+	for _, key := range sortedTestKeys(currentTests) {
+		currentTest := currentTests[key]
+		baselineTest, ok := baselineTests[key]
+		if !ok {
+			testRunDiff.Added = append(testRunDiff.Added, TestDiff{
+				PackageName:   currentTest.PackageName,
+				Name:          currentTest.Name,
+				CurrentStatus: currentTest.Status,
+			})
+			continue
+		}
+
+		testDiff := TestDiff{
+			PackageName:    currentTest.PackageName,
+			Name:           currentTest.Name,
+			BaselineStatus: baselineTest.Status,
+			CurrentStatus:  currentTest.Status,
+		}
+		if isFailedStatus(currentTest.Status) && !isFailedStatus(baselineTest.Status) {
+			testRunDiff.NewlyFailing = append(testRunDiff.NewlyFailing, testDiff)
+		} else if currentTest.Status == "pass" && isFailedStatus(baselineTest.Status) {
+			testRunDiff.NewlyPassing = append(testRunDiff.NewlyPassing, testDiff)
+		}
+
+		baselineSeconds := elapsedSeconds(baselineTest.ElapsedTime, baselineTest.TimeSymbol)
+		currentSeconds := elapsedSeconds(currentTest.ElapsedTime, currentTest.TimeSymbol)
+		if currentSeconds-baselineSeconds >= minDurationIncrease && currentSeconds > baselineSeconds*(1+durationThreshold/100) {
+			increase := 0.0
+			if baselineSeconds > 0 {
+				increase = (currentSeconds - baselineSeconds) / baselineSeconds * 100
+			}
+			testRunDiff.DurationRegressions = append(testRunDiff.DurationRegressions, DurationRegression{
+				PackageName: currentTest.PackageName,
+				Name:        currentTest.Name,
+				Baseline:    baselineSeconds,
+				Current:     currentSeconds,
+				Increase:    increase,
+			})
+		}
+	}
+
+	for _, key := range sortedTestKeys(baselineTests) {
+		if _, ok := currentTests[key]; !ok {
+			testRunDiff.Removed = append(testRunDiff.Removed, TestDiff{
+				PackageName:    baselineTests[key].PackageName,
+				Name:           baselineTests[key].Name,
+				BaselineStatus: baselineTests[key].Status,
+			})
+		}
+	}
+
+	for _, packageName := range sortedPackageNames(current.PackageDetailsMap) {
+		currentCoverage, currentOk := packageCoveragePercent(current.PackageDetailsMap[packageName])
+		baselineCoverage, baselineOk := packageCoveragePercent(baseline.PackageDetailsMap[packageName])
+		if currentOk && baselineOk && currentCoverage != baselineCoverage {
+			testRunDiff.CoverageDeltas = append(testRunDiff.CoverageDeltas, CoverageDelta{
+				PackageName: packageName,
+				Baseline:    baselineCoverage,
+				Current:     currentCoverage,
+				Delta:       currentCoverage - baselineCoverage,
+			})
+		}
+	}
+
+	return testRunDiff
+}
+
+// flattenTests indexes a test tree by package and test name
+func flattenTests(testsMap map[string]*TestDetails, tests []*TestDetails) map[string]*TestDetails {
+	for _, test := range tests {
+		testsMap[test.PackageName+"-"+test.Name] = test
+		flattenTests(testsMap, test.Subtests)
+	}
+
+	return testsMap
+}
+
+func sortedTestKeys(testsMap map[string]*TestDetails) []string {
+	keys := make([]string, 0, len(testsMap))
+	for key := range testsMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// isFailedStatus reports whether a test status is a failure, including tests that never finished
+func isFailedStatus(status string) bool {
+	return status == "fail" || status == "timeout" || status == "panic" || status == "incomplete"
+}
+
+// GenerateDiffReports writes the diff of two test runs in every format requested with the format flag
+func GenerateDiffReports(testRunDiff *TestRunDiff) error {
+	for _, format := range reportFormats {
+		switch format {
+		case "html":
+			err := generateDiffHTMLReport(testRunDiff)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating diff html")
+				return err
+			}
+		case "json":
+			diffData, err := json.MarshalIndent(testRunDiff, "", "  ")
+			if err != nil {
+				log.Error().Err(err).Msg("error marshalling diff json")
+				return err
+			}
+			err = ioutil.WriteFile(reportPath("diff.json"), diffData, 0644)
+			if err != nil {
+				log.Error().Err(err).Msg("error writing diff.json file")
+				return err
+			}
+		case "markdown":
+			err := ioutil.WriteFile(reportPath("diff.md"), []byte(generateDiffMarkdown(testRunDiff)), 0644)
+			if err != nil {
+				log.Error().Err(err).Msg("error writing diff.md file")
+				return err
+			}
+		default:
+			err := fmt.Errorf("report format %q is not supported for diffs", format)
+			log.Error().Err(err).Msg("error generating diff reports")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateDiffHTMLReport(testRunDiff *TestRunDiff) error {
+	diffTemplateData, err := assets.Asset("diff-template.html")
+	if err != nil {
+		log.Error().Err(err).Msg("error retrieving diff-template.html")
+		return err
+	}
+
+	diffTemplate, err := template.New("diff-template.html").Parse(string(diffTemplateData))
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing diff-template.html")
+		return err
+	}
+
+	var processedTemplate bytes.Buffer
+	err = diffTemplate.Execute(&processedTemplate, testRunDiff)
+	if err != nil {
+		log.Error().Err(err).Msg("error applying diff-template.html")
+		return err
+	}
+
+	err = ioutil.WriteFile(reportPath("diff.html"), processedTemplate.Bytes(), 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error writing diff.html file")
+		return err
+	}
+
+	return nil
+}
+
+func generateDiffMarkdown(testRunDiff *TestRunDiff) string {
+	var diff bytes.Buffer
+
+	fmt.Fprintf(&diff, "## Go Test Diff\n\n")
+	fmt.Fprintf(&diff, "**Baseline:** %s | **Current:** %s\n\n", testRunDiff.BaselineDate, testRunDiff.CurrentDate)
+
+	writeTestDiffs := func(title string, testDiffs []TestDiff) {
+		fmt.Fprintf(&diff, "### %s (%d)\n\n", title, len(testDiffs))
+		if len(testDiffs) == 0 {
+			fmt.Fprintf(&diff, "None\n\n")
+			return
+		}
+		fmt.Fprintf(&diff, "| Package | Test | Baseline | Current |\n|---|---|---|---|\n")
+		for _, testDiff := range testDiffs {
+			fmt.Fprintf(&diff, "| `%s` | `%s` | %s | %s |\n", testDiff.PackageName, testDiff.Name, testDiff.BaselineStatus, testDiff.CurrentStatus)
+		}
+		fmt.Fprintf(&diff, "\n")
+	}
+	writeTestDiffs("Newly failing tests", testRunDiff.NewlyFailing)
+	writeTestDiffs("Newly passing tests", testRunDiff.NewlyPassing)
+	writeTestDiffs("Added tests", testRunDiff.Added)
+	writeTestDiffs("Removed tests", testRunDiff.Removed)
+
+	fmt.Fprintf(&diff, "### Coverage changes (%d)\n\n", len(testRunDiff.CoverageDeltas))
+	if len(testRunDiff.CoverageDeltas) == 0 {
+		fmt.Fprintf(&diff, "None\n\n")
+	} else {
+		fmt.Fprintf(&diff, "| Package | Baseline | Current | Delta |\n|---|---:|---:|---:|\n")
+		for _, coverageDelta := range testRunDiff.CoverageDeltas {
+			fmt.Fprintf(&diff, "| `%s` | %.1f%% | %.1f%% | %+.1f%% |\n", coverageDelta.PackageName, coverageDelta.Baseline, coverageDelta.Current, coverageDelta.Delta)
+		}
+		fmt.Fprintf(&diff, "\n")
+	}
+
+	fmt.Fprintf(&diff, "### Duration regressions (%d)\n\n", len(testRunDiff.DurationRegressions))
+	if len(testRunDiff.DurationRegressions) == 0 {
+		fmt.Fprintf(&diff, "None\n")
+	} else {
+		fmt.Fprintf(&diff, "| Package | Test | Baseline | Current | Increase |\n|---|---|---:|---:|---:|\n")
+		for _, durationRegression := range testRunDiff.DurationRegressions {
+			increase := "n/a"
+			if durationRegression.Baseline > 0 {
+				increase = fmt.Sprintf("%+.1f%%", durationRegression.Increase)
+			}
+			fmt.Fprintf(&diff, "| `%s` | `%s` | %.3fs | %.3fs | %s |\n", durationRegression.PackageName, durationRegression.Name, durationRegression.Baseline, durationRegression.Current, increase)
+		}
+	}
+
+	return diff.String()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"github.com/rs/zerolog/log"
+	"html/template"
+	"strings"
+)
+
+// ExampleOutput holds the output a failing example printed and the output its // Output: comment expects
+type ExampleOutput struct {
+	Got       []string `json:"got"`
+	Want      []string `json:"want"`
+	Unordered bool     `json:"unordered,omitempty"`
+}
+
+// a line of the diff of the wanted and the printed output of an example, Op is one of "=", "-" and "+"
+type exampleDiffLine struct {
+	Op   string
+	Want string
+	Got  string
+}
+
+// parseExampleOutput extracts the got and want blocks go test prints when the output of an example does not match,
+//
+//	got:
+//	hello
+//	want:
+//	hi
+//
+// it returns nil for examples that passed or failed by panicking
+func parseExampleOutput(status string, output []string) *ExampleOutput {
+	if status != "fail" && status != "flaky" {
+		return nil
+	}
+
+	gotIndex := -1
+	wantIndex := -1
+	exampleOutput := &ExampleOutput{}
+	for i, line := range output {
+		if line == "got:" {
+			gotIndex = i
+			wantIndex = -1
+		} else if gotIndex != -1 && (line == "want:" || line == "want (unordered):") {
+			wantIndex = i
+			exampleOutput.Unordered = line == "want (unordered):"
+		}
+	}
+	if gotIndex == -1 || wantIndex == -1 {
+		return nil
+	}
+
+	exampleOutput.Got = output[gotIndex+1 : wantIndex]
+	exampleOutput.Want = output[wantIndex+1:]
+	// the want block ends with the end of the output, drop the result lines test2json may attribute to the example
+	for len(exampleOutput.Want) > 0 {
+		last := exampleOutput.Want[len(exampleOutput.Want)-1]
+		if last != "" && last != "FAIL" && !strings.HasPrefix(last, "--- FAIL:") {
+			break
+		}
+		exampleOutput.Want = exampleOutput.Want[:len(exampleOutput.Want)-1]
+	}
+
+	return exampleOutput
+}
+
+// diffExampleOutput returns the line diff turning the wanted output into the printed output, from their longest
+// common subsequence of lines
+func diffExampleOutput(want, got []string) []exampleDiffLine {
+	// common[i][j] is the length of the longest common subsequence of want[i:] and got[j:]
+	common := make([][]int, len(want)+1)
+	for i := range common {
+		common[i] = make([]int, len(got)+1)
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		for j := len(got) - 1; j >= 0; j-- {
+			if want[i] == got[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	diffLines := make([]exampleDiffLine, 0, len(want)+len(got))
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		if i < len(want) && j < len(got) && want[i] == got[j] {
+			diffLines = append(diffLines, exampleDiffLine{Op: "=", Want: want[i], Got: got[j]})
+			i, j = i+1, j+1
+		} else if j == len(got) || (i < len(want) && common[i+1][j] >= common[i][j+1]) {
+			diffLines = append(diffLines, exampleDiffLine{Op: "-", Want: want[i]})
+			i = i + 1
+		} else {
+			diffLines = append(diffLines, exampleDiffLine{Op: "+", Got: got[j]})
+			j = j + 1
+		}
+	}
+
+	return diffLines
+}
+
+// generate the side by side diff of the wanted and the printed output of a failing example
+func generateExampleDiffHTMLElement(exampleOutput *ExampleOutput) (template.HTML, error) {
+	if exampleOutput == nil {
+		return "", nil
+	}
+
+	exampleDiffTemplate, err := template.New("exampleDiff").Parse(`
+										<div class="exampleDiff">
+											<table>
+												<tr><th>want{{if .unordered}} (unordered){{end}}</th><th>got</th></tr>
+												{{range .lines}}
+												<tr>
+													<td class="{{if eq .Op "-"}}exampleDiffRemoved{{end}}"><pre>{{.Want}}</pre></td>
+													<td class="{{if eq .Op "+"}}exampleDiffAdded{{end}}"><pre>{{.Got}}</pre></td>
+												</tr>
+												{{end}}
+											</table>
+										</div>
+									`)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing example diff template")
+		return "", err
+	}
+
+	var processedExampleDiffTemplate bytes.Buffer
+	err = exampleDiffTemplate.Execute(&processedExampleDiffTemplate, map[string]interface{}{
+		"unordered": exampleOutput.Unordered,
+		"lines":     diffExampleOutput(exampleOutput.Want, exampleOutput.Got),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error applying example diff template")
+		return "", err
+	}
+
+	return template.HTML(processedExampleDiffTemplate.String()), nil
+}
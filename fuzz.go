@@ -0,0 +1,108 @@
+package main
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FuzzDetails holds the statistics of the last progress line of a fuzzing run and the input that made it fail
+type FuzzDetails struct {
+	Elapsed          string `json:"elapsed,omitempty"`
+	Execs            int64  `json:"execs"`
+	ExecsPerSec      int64  `json:"execsPerSec"`
+	NewInteresting   int    `json:"newInteresting"`
+	TotalInteresting int    `json:"totalInteresting"`
+	FailingInput     string `json:"failingInput,omitempty"`
+	RerunCommand     string `json:"rerunCommand,omitempty"`
+}
+
+// the progress line the fuzzing engine writes every few seconds,
+// e.g. "fuzz: elapsed: 3s, execs: 325017 (108336/sec), new interesting: 11 (total: 202)"
+var fuzzProgressLine = regexp.MustCompile(`^fuzz: elapsed: (\S+), execs: (\d+) \((\d+)/sec\), new interesting: (\d+) \(total: (\d+)\)`)
+
+// testKind returns the kind of test function a test or subtest belongs to: test, benchmark, fuzz or example.
+// Like go test, a prefix only counts when it is not followed by a lower case letter, TestingHelper is a test but
+// Testinghelper is not a test function at all
+func testKind(testName string) string {
+	topLevelName := strings.SplitN(testName, "/", 2)[0]
+	if hasTestFunctionPrefix(topLevelName, "Fuzz") {
+		return "fuzz"
+	} else if hasTestFunctionPrefix(topLevelName, "Benchmark") {
+		return "benchmark"
+	} else if hasTestFunctionPrefix(topLevelName, "Example") {
+		return "example"
+	}
+
+	return "test"
+}
+
+func hasTestFunctionPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+
+	return !unicode.IsLower(r)
+}
+
+// parseFuzzDetails extracts the fuzzing statistics and the failing input from the output of a fuzz target,
+// it returns nil when the target only ran its seed corpus
+func parseFuzzDetails(output []string) *FuzzDetails {
+	var fuzzDetails *FuzzDetails
+	for i, line := range output {
+		line = strings.TrimSpace(line)
+		if match := fuzzProgressLine.FindStringSubmatch(line); match != nil {
+			if fuzzDetails == nil {
+				fuzzDetails = &FuzzDetails{}
+			}
+			fuzzDetails.Elapsed = match[1]
+			fuzzDetails.Execs, _ = strconv.ParseInt(match[2], 10, 64)
+			fuzzDetails.ExecsPerSec, _ = strconv.ParseInt(match[3], 10, 64)
+			fuzzDetails.NewInteresting, _ = strconv.Atoi(match[4])
+			fuzzDetails.TotalInteresting, _ = strconv.Atoi(match[5])
+		} else if strings.HasPrefix(line, "Failing input written to ") {
+			if fuzzDetails == nil {
+				fuzzDetails = &FuzzDetails{}
+			}
+			fuzzDetails.FailingInput = strings.TrimPrefix(line, "Failing input written to ")
+			// followed by "To re-run:" and the go test command
+			if i+2 < len(output) && strings.TrimSpace(output[i+1]) == "To re-run:" {
+				fuzzDetails.RerunCommand = strings.TrimSpace(output[i+2])
+			}
+		}
+	}
+
+	return fuzzDetails
+}
+
+// failingInputLink returns the link from the report to the failing input of a fuzz target, which go test writes below
+// the directory of its package, or "" when the input is not found below the source directory
+func failingInputLink(packageName, failingInput string) string {
+	inputFile, ok := findSourceFile(path.Join(packageName, filepath.ToSlash(failingInput)))
+	if !ok {
+		return ""
+	}
+
+	reportDirectory, err := filepath.Abs(filepath.Dir(reportPath("report.html")))
+	if err != nil {
+		return ""
+	}
+	inputFile, err = filepath.Abs(inputFile)
+	if err != nil {
+		return ""
+	}
+	link, err := filepath.Rel(reportDirectory, inputFile)
+	if err != nil {
+		return ""
+	}
+
+	return filepath.ToSlash(link)
+}
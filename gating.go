@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// the policies accepted by the fail-on flag
+const (
+	failOnTestFailure  = "test-failure"
+	failOnBuildFailure = "build-failure"
+	failOnCoverage     = "coverage"
+	failOnSkips        = "skips"
+)
+
+// ValidateFailOnPolicies returns an error for the first unknown fail-on policy
+func ValidateFailOnPolicies(policies []string) error {
+	for _, policy := range policies {
+		switch policy {
+		case failOnTestFailure, failOnBuildFailure, failOnCoverage, failOnSkips:
+		default:
+			return fmt.Errorf("unknown fail-on policy %q, expected one of %s, %s, %s, %s",
+				policy, failOnTestFailure, failOnBuildFailure, failOnCoverage, failOnSkips)
+		}
+	}
+
+	return nil
+}
+
+// CheckFailOnPolicies prints the fail-on policy violations of the processed test data to stderr and
+// returns an error when there is any, making the command exit with a non-zero status
+func CheckFailOnPolicies(processedTestdata *ProcessedTestdata) error {
+	reasons := EvaluateFailOnPolicies(processedTestdata, failOnPolicies, maxSkipped)
+	for _, reason := range reasons {
+		fmt.Fprintf(os.Stderr, "fail-on: %s\n", reason)
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("%d fail-on policy violations", len(reasons))
+	}
+
+	return nil
+}
+
+// EvaluateFailOnPolicies checks the processed test data against the fail-on policies and
+// returns the reasons why the run should exit with a non-zero status
+func EvaluateFailOnPolicies(processedTestdata *ProcessedTestdata, policies []string, maxSkipped int) []string {
+	reasons := make([]string, 0)
+	packageNames := sortedPackageNames(processedTestdata.PackageDetailsMap)
+
+	for _, policy := range policies {
+		switch policy {
+		case failOnTestFailure:
+			if processedTestdata.FailedTests > 0 {
+				reasons = append(reasons, fmt.Sprintf("%d tests failed", processedTestdata.FailedTests))
+			}
+			// go test fails a run in which any attempt of a test failed
+			if processedTestdata.FlakyTests > 0 {
+				reasons = append(reasons, fmt.Sprintf("%d tests failed in some of their attempts", processedTestdata.FlakyTests))
+			}
+			if processedTestdata.IncompleteTests > 0 {
+				reasons = append(reasons, fmt.Sprintf("%d tests panicked, timed out or never finished", processedTestdata.IncompleteTests))
+			}
+		case failOnBuildFailure:
+			for _, packageName := range packageNames {
+				if processedTestdata.PackageDetailsMap[packageName].BuildFailed {
+					reasons = append(reasons, fmt.Sprintf("package %s failed to build", packageName))
+				}
+			}
+		case failOnCoverage:
+			for _, violation := range processedTestdata.CoverageViolations {
+				reasons = append(reasons, fmt.Sprintf("package %s coverage %.1f%% is below %.1f%%", violation.PackageName, violation.Coverage, violation.Floor))
+			}
+		case failOnSkips:
+			if processedTestdata.SkippedTests > maxSkipped {
+				reasons = append(reasons, fmt.Sprintf("%d tests skipped, at most %d allowed", processedTestdata.SkippedTests, maxSkipped))
+			}
+		}
+	}
+
+	return reasons
+}
+
+// parseCoveragePercent parses the display form of a package coverage such as " 63.2%" into a number
+func parseCoveragePercent(coverage string) (float64, bool) {
+	coverage = strings.TrimSuffix(strings.TrimSpace(coverage), "%")
+	percent, err := strconv.ParseFloat(coverage, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return percent, true
+}
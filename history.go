@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Thatooine/go-test-html-report/assets"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"html/template"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// colors of the lines of a chart, reused when there are more series than colors
+var chartColors = []string{"limegreen", "red", "darkgrey", "deepskyblue", "orange", "violet", "gold", "turquoise", "salmon", "white"}
+
+const (
+	chartWidth         = 900
+	chartHeight        = 260
+	chartPaddingLeft   = 60
+	chartPaddingRight  = 20
+	chartPaddingTop    = 20
+	chartPaddingBottom = 50
+	chartGridLines     = 4
+	chartMaxLabels     = 12
+	// the legend fits one row below the run labels, the chart grows by a row height for every further row
+	chartLegendColumns   = 6
+	chartLegendRowHeight = 14
+)
+
+type HistoryRun struct {
+	StartTime       time.Time
+	Date            string
+	PassedTests     int
+	FailedTests     int
+	SkippedTests    int
+	DurationSeconds float64
+	Coverage        map[string]float64
+}
+
+type chartSeries struct {
+	Name   string
+	Values []float64
+	// runs without a value, e.g. a package without coverage, are left out of the line
+	Missing []bool
+}
+
+func newHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [go test json logs or report.json files, glob patterns or directories]",
+		Short: "history renders the trends of many test runs",
+		Long: "history reads many test runs, each a go test json log or a report.json, orders them by the time of their " +
+			"first test event and renders history.html with the test counts, total test time and package coverage over time",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			historyRuns := make([]HistoryRun, 0)
+			for _, pattern := range args {
+				files, err := expandLogFilePattern(pattern)
+				if err != nil {
+					return err
+				}
+
+				for _, file := range files {
+					processedTestdata, err := ReadTestRun(file)
+					if err != nil {
+						log.Error().Err(err).Msgf("error reading test run %s", file)
+						return err
+					}
+					historyRuns = append(historyRuns, newHistoryRun(processedTestdata))
+				}
+			}
+
+			sort.SliceStable(historyRuns, func(i, j int) bool {
+				return historyRuns[i].StartTime.Before(historyRuns[j].StartTime)
+			})
+
+			err := GenerateHistoryReport(historyRuns)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating history html")
+				return err
+			}
+
+			log.Info().Msgf("History report generated successfully")
+			return nil
+		},
+	}
+}
+
+// newHistoryRun summarises the processed test data of a run for the history charts
+func newHistoryRun(processedTestdata *ProcessedTestdata) HistoryRun {
+	startTime := processedTestdata.StartTime
+	if startTime.IsZero() {
+		// summaries written before the start time was recorded only hold the formatted test date
+		startTime, _ = time.Parse(time.RFC850, processedTestdata.TestDate)
+	}
+
+	historyRun := HistoryRun{
+		StartTime:    startTime,
+		Date:         startTime.Format("2006-01-02 15:04"),
+		PassedTests:  processedTestdata.PassedTests,
+		FailedTests:  processedTestdata.FailedTests,
+		SkippedTests: processedTestdata.SkippedTests,
+		Coverage:     map[string]float64{},
+	}
+	if !processedTestdata.StartTime.IsZero() {
+		historyRun.DurationSeconds = processedTestdata.EndTime.Sub(processedTestdata.StartTime).Seconds()
+	}
+	for packageName, packageDetails := range processedTestdata.PackageDetailsMap {
+		if coverage, ok := packageCoveragePercent(packageDetails); ok {
+			historyRun.Coverage[packageName] = coverage
+		}
+	}
+
+	return historyRun
+}
+
+// GenerateHistoryReport writes history.html in the output directory
+func GenerateHistoryReport(historyRuns []HistoryRun) error {
+	labels := make([]string, 0, len(historyRuns))
+	testCounts := []chartSeries{{Name: "Passed"}, {Name: "Failed"}, {Name: "Skipped"}}
+	duration := []chartSeries{{Name: "Total test time"}}
+	packageNames := make([]string, 0)
+	for _, historyRun := range historyRuns {
+		labels = append(labels, historyRun.Date)
+		testCounts[0].Values = append(testCounts[0].Values, float64(historyRun.PassedTests))
+		testCounts[1].Values = append(testCounts[1].Values, float64(historyRun.FailedTests))
+		testCounts[2].Values = append(testCounts[2].Values, float64(historyRun.SkippedTests))
+		duration[0].Values = append(duration[0].Values, historyRun.DurationSeconds)
+		for packageName := range historyRun.Coverage {
+			if !containsLine(packageNames, packageName) {
+				packageNames = append(packageNames, packageName)
+			}
+		}
+	}
+	sort.Strings(packageNames)
+
+	coverage := make([]chartSeries, 0, len(packageNames))
+	for _, packageName := range packageNames {
+		series := chartSeries{Name: packageName}
+		for _, historyRun := range historyRuns {
+			value, ok := historyRun.Coverage[packageName]
+			series.Values = append(series.Values, value)
+			series.Missing = append(series.Missing, !ok)
+		}
+		coverage = append(coverage, series)
+	}
+
+	historyTemplateData, err := assets.Asset("history-template.html")
+	if err != nil {
+		log.Error().Err(err).Msg("error retrieving history-template.html")
+		return err
+	}
+
+	historyTemplate, err := template.New("history-template.html").Parse(string(historyTemplateData))
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing history-template.html")
+		return err
+	}
+
+	var processedTemplate bytes.Buffer
+	err = historyTemplate.Execute(&processedTemplate, map[string]interface{}{
+		"Runs":           historyRuns,
+		"TestCountChart": generateLineChartSVG(labels, testCounts, 0, ""),
+		"DurationChart":  generateLineChartSVG(labels, duration, 0, "s"),
+		"CoverageChart":  generateLineChartSVG(labels, coverage, 100, "%"),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error applying history-template.html")
+		return err
+	}
+
+	err = ioutil.WriteFile(reportPath("history.html"), processedTemplate.Bytes(), 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error writing history.html file")
+		return err
+	}
+
+	return nil
+}
+
+// generateLineChartSVG draws an inline svg line chart of the series over the runs named by the labels.
+// The y axis goes up to maxValue, or to the largest value of the series when maxValue is 0.
+func generateLineChartSVG(labels []string, series []chartSeries, maxValue float64, unit string) template.HTML {
+	if maxValue == 0 {
+		for _, s := range series {
+			for _, value := range s.Values {
+				maxValue = math.Max(maxValue, value)
+			}
+		}
+		if maxValue == 0 {
+			maxValue = 1
+		}
+	}
+
+	plotWidth := float64(chartWidth - chartPaddingLeft - chartPaddingRight)
+	plotHeight := float64(chartHeight - chartPaddingTop - chartPaddingBottom)
+	x := func(i int) float64 {
+		if len(labels) < 2 {
+			return chartPaddingLeft + plotWidth/2
+		}
+		return chartPaddingLeft + plotWidth*float64(i)/float64(len(labels)-1)
+	}
+	y := func(value float64) float64 {
+		return chartPaddingTop + plotHeight*(1-value/maxValue)
+	}
+
+	svgHeight := chartHeight
+	if legendRows := (len(series) + chartLegendColumns - 1) / chartLegendColumns; legendRows > 1 {
+		svgHeight = chartHeight + (legendRows-1)*chartLegendRowHeight
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg class="chart" viewBox="0 0 %d %d" width="100%%" xmlns="http://www.w3.org/2000/svg">`, chartWidth, svgHeight)
+
+	// horizontal grid lines with their values
+	for i := 0; i <= chartGridLines; i++ {
+		value := maxValue * float64(i) / chartGridLines
+		fmt.Fprintf(&svg, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#3c3870"/>`, chartPaddingLeft, y(value), chartWidth-chartPaddingRight, y(value))
+		fmt.Fprintf(&svg, `<text x="%d" y="%.1f" fill="white" font-size="11" text-anchor="end">%s%s</text>`, chartPaddingLeft-6, y(value)+4, formatChartValue(value), template.HTMLEscapeString(unit))
+	}
+
+	// run labels, thinned out when there are too many to fit
+	labelStep := int(math.Ceil(float64(len(labels)) / chartMaxLabels))
+	for i, label := range labels {
+		if i%labelStep != 0 {
+			continue
+		}
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%d" fill="white" font-size="11" text-anchor="middle">%s</text>`, x(i), chartHeight-chartPaddingBottom+18, template.HTMLEscapeString(label))
+	}
+
+	for s, chartLine := range series {
+		color := chartColors[s%len(chartColors)]
+		points := make([]string, 0, len(chartLine.Values))
+		flush := func() {
+			if len(points) > 1 {
+				fmt.Fprintf(&svg, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), color)
+			}
+			points = points[:0]
+		}
+		for i, value := range chartLine.Values {
+			if i < len(chartLine.Missing) && chartLine.Missing[i] {
+				flush()
+				continue
+			}
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(i), y(value)))
+			fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s %s: %s%s</title></circle>`,
+				x(i), y(value), color, template.HTMLEscapeString(chartLine.Name), template.HTMLEscapeString(labels[i]), formatChartValue(value), template.HTMLEscapeString(unit))
+		}
+		flush()
+
+		// legend below the run labels
+		legendX := chartPaddingLeft + (s%chartLegendColumns)*140
+		legendY := chartHeight - 20 + (s/chartLegendColumns)*chartLegendRowHeight
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`, legendX, legendY, color)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" fill="white" font-size="11">%s</text>`, legendX+14, legendY+9, template.HTMLEscapeString(chartLine.Name))
+	}
+
+	svg.WriteString(`</svg>`)
+
+	return template.HTML(svg.String())
+}
+
+func formatChartValue(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	} else if value < 10 {
+		return fmt.Sprintf("%.2f", value)
+	}
+
+	return fmt.Sprintf("%.1f", value)
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"github.com/Thatooine/go-test-html-report/assets"
 	"github.com/rs/zerolog/log"
@@ -12,47 +11,105 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type GoTestJsonRowData struct {
-	Time    time.Time
-	Action  string
-	Package string
-	Test    string
-	Output  string
-	Elapsed float64
+	Time        time.Time
+	Action      string
+	Package     string
+	ImportPath  string
+	Test        string
+	Output      string
+	Elapsed     float64
+	FailedBuild string
+	// the kind of an output line, newer versions of test2json set it to error and error-continue for the
+	// lines logged by t.Error and t.Fatal
+	OutputType string
+
+	// the log file the event was read from
+	Shard string `json:"-"`
 }
 
 type ProcessedTestdata struct {
-	TotalTestTime     string
-	TestDate          string
-	FailedTests       int
-	PassedTests       int
-	TestSummary       []TestOverview
-	PackageDetailsMap map[string]PackageDetails
+	TotalTestTime     string                    `json:"totalTestTime"`
+	TestDate          string                    `json:"testDate"`
+	FailedTests       int                       `json:"failedTests"`
+	PassedTests       int                       `json:"passedTests"`
+	SkippedTests      int                       `json:"skippedTests"`
+	FlakyTests        int                       `json:"flakyTests"`
+	StartTime         time.Time                 `json:"startTime"`
+	EndTime           time.Time                 `json:"endTime"`
+	IncompleteTests   int                       `json:"incompleteTests"`
+	BuildFailed       int                       `json:"buildFailedPackages"`
+	TestSummary       []*TestDetails            `json:"tests"`
+	PackageDetailsMap map[string]PackageDetails `json:"packages"`
+
+	CoverageViolations []CoverageViolation `json:"coverageViolations"`
+	TotalCoverage      *TotalCoverage      `json:"totalCoverage,omitempty"`
 }
 
 type PackageDetails struct {
-	Name        string
-	ElapsedTime float64
-	TimeSymbol  string
-	Status      string
-	Coverage    string
+	Name          string            `json:"name"`
+	ElapsedTime   float64           `json:"elapsedTime"`
+	TimeSymbol    string            `json:"timeSymbol"`
+	Status        string            `json:"status"`
+	Coverage      string            `json:"coverage"`
+	CoverageValue CoverageValue     `json:"coverageValue"`
+	Output        []string          `json:"output,omitempty"`
+	BuildFailed   bool              `json:"buildFailed,omitempty"`
+	BuildOutput   []string          `json:"buildOutput,omitempty"`
+	Shards        []string          `json:"shards,omitempty"`
+	Benchmarks    []BenchmarkResult `json:"benchmarks,omitempty"`
+
+	// statement coverage of the cover profiles
+	Statements        int            `json:"statements,omitempty"`
+	CoveredStatements int            `json:"coveredStatements,omitempty"`
+	Files             []FileCoverage `json:"files,omitempty"`
+
+	// the minimum coverage of the coverage thresholds
+	CoverageFloor      float64 `json:"coverageFloor,omitempty"`
+	BelowCoverageFloor bool    `json:"belowCoverageFloor,omitempty"`
 }
 
 type TestDetails struct {
-	PackageName string
-	Name        string
-	ElapsedTime float64
-	TimeSymbol  string
-	Status      string
+	PackageName string            `json:"package"`
+	Name        string            `json:"name"`
+	ElapsedTime float64           `json:"elapsedTime"`
+	TimeSymbol  string            `json:"timeSymbol"`
+	Status      string            `json:"status"`
+	Output      []string          `json:"output,omitempty"`
+	Failures    []FailureLocation `json:"failures,omitempty"`
+	SkipReason  string            `json:"skipReason,omitempty"`
+	Dump        []string          `json:"dump,omitempty"`
+	Subtests    []*TestDetails    `json:"subtests,omitempty"`
+	Kind        string            `json:"kind"`
+	Fuzz        *FuzzDetails      `json:"fuzz,omitempty"`
+	Example     *ExampleOutput    `json:"example,omitempty"`
+
+	// executions of the test, more than one when run with -count or retried
+	Attempts       int `json:"attempts"`
+	PassedAttempts int `json:"passedAttempts"`
+	FailedAttempts int `json:"failedAttempts"`
+
+	// set once a run event was seen, tests that started but never finished are marked incomplete
+	started bool
+	// the elapsed seconds of all attempts
+	totalElapsed float64
+	// the output lines test2json marked as logged by t.Error and t.Fatal, once it marked any output line at all
+	errorOutput []string
+	outputTyped bool
 }
 
-type TestOverview struct {
-	TestSuite TestDetails
-	TestCases []TestDetails
+type FailureLocation struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
 }
 
 func main() {
@@ -62,8 +119,13 @@ func main() {
 	}
 }
 
-var fileName string
+var fileNames []string
 var outputDirectory string
+var reportFormats []string
+var githubStepSummary bool
+var failOnPolicies []string
+var minCoverage float64
+var maxSkipped int
 
 func initCommand() *cobra.Command {
 	var rootCmd = &cobra.Command{
@@ -71,13 +133,23 @@ func initCommand() *cobra.Command {
 		Long:  "go-test-html-report generates a html report of go-test logs",
 		Short: "go-test-html-report generates a html report of go-test logs",
 		RunE: func(cmd *cobra.Command, args []string) (e error) {
+			err := ValidateFailOnPolicies(failOnPolicies)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			coverageThresholds, err := ReadCoverageThresholds(coverageThresholdsFile)
+			if err != nil {
+				return err
+			}
+
 			testData := make([]GoTestJsonRowData, 0)
 
-			file, _ := cmd.Flags().GetString("file")
-			if file != "" {
-				fileLogData, err := ReadLogsFromFile(file)
+			if len(fileNames) > 0 {
+				fileLogData, err := ReadLogsFromFiles(fileNames)
 				if err != nil {
-					log.Error().Err(err).Msg("error reading logs from a file")
+					log.Error().Err(err).Msg("error reading logs from files")
 					return err
 				}
 
@@ -98,39 +170,221 @@ func initCommand() *cobra.Command {
 				return err
 			}
 
-			err = GenerateHTMLReport(processedTestdata.TotalTestTime,
-				processedTestdata.TestDate,
-				processedTestdata.FailedTests,
-				processedTestdata.PassedTests,
-				processedTestdata.TestSummary,
-				processedTestdata.PackageDetailsMap,
-			)
+			if len(coverProfiles) > 0 {
+				coverageBlocks, err := ReadCoverProfiles(coverProfiles)
+				if err != nil {
+					log.Error().Err(err).Msg("error reading cover profiles")
+					return err
+				}
+				ApplyCoverProfiles(processedTestdata, coverageBlocks)
+			}
+			var coverFuncCoverage *TotalCoverage
+			if len(coverFuncOutputs) > 0 {
+				coverFuncCoverage, err = ReadCoverFuncOutputs(coverFuncOutputs)
+				if err != nil {
+					log.Error().Err(err).Msg("error reading go tool cover -func outputs")
+					return err
+				}
+			}
+			ApplyTotalCoverage(processedTestdata, coverFuncCoverage)
+			ApplyCoverageThresholds(processedTestdata, coverageThresholds)
+
+			err = GenerateReports(processedTestdata)
 			if err != nil {
-				log.Error().Err(err).Msg("error generating report html")
 				return err
 			}
 
 			log.Info().Msgf("Report generated successfully")
-			return nil
+
+			return CheckFailOnPolicies(processedTestdata)
 		},
 	}
-	rootCmd.PersistentFlags().StringVarP(
-		&fileName,
+	rootCmd.PersistentFlags().StringSliceVarP(
+		&fileNames,
 		"file",
 		"f",
-		"",
-		"set the file of the go test json logs",
+		[]string{},
+		"set the files of the go test json or go test -v text logs, repeat the flag or pass glob patterns and directories "+
+			"to merge several logs. Text logs have no timestamps, their run is taken to end at the modification time of "+
+			"the file, or when standard input is closed for piped logs",
 	)
-	rootCmd.Flags().StringVarP(
+	rootCmd.PersistentFlags().StringVarP(
 		&outputDirectory,
 		"output",
 		"o",
 		"",
-		"set the output directory of the html report",
+		"set the output directory of the reports",
+	)
+	rootCmd.PersistentFlags().StringSliceVar(
+		&reportFormats,
+		"format",
+		[]string{"html"},
+		"set the formats of the generated reports: html, json, junit, markdown",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&githubStepSummary,
+		"github-step-summary",
+		false,
+		"append the markdown summary to the file named by $GITHUB_STEP_SUMMARY",
+	)
+	rootCmd.PersistentFlags().StringSliceVar(
+		&failOnPolicies,
+		"fail-on",
+		[]string{},
+		"exit with a non-zero status after generating the reports when: test-failure, build-failure, coverage, skips",
+	)
+	rootCmd.PersistentFlags().Float64Var(
+		&minCoverage,
+		"min-coverage",
+		0,
+		"set the default package coverage percentage below which a package violates its coverage floor",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&coverageThresholdsFile,
+		"coverage-thresholds",
+		"",
+		"set the json file of the default and per package coverage floors, overriding --min-coverage",
+	)
+	rootCmd.PersistentFlags().IntVar(
+		&maxSkipped,
+		"max-skipped",
+		0,
+		"set the number of skipped tests above which the skips fail-on policy fails",
 	)
+	rootCmd.PersistentFlags().StringSliceVar(
+		&coverProfiles,
+		"coverprofile",
+		[]string{},
+		"set the cover profiles written by go test -coverprofile to break the coverage down by file and function",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&sourceDirectory,
+		"source-dir",
+		".",
+		"set the module root holding the sources of the cover profiles, used for the coverage of functions",
+	)
+	rootCmd.PersistentFlags().StringSliceVar(
+		&coverFuncOutputs,
+		"cover-func",
+		[]string{},
+		"set the output of go tool cover -func to report its total coverage of the run without the cover profiles",
+	)
+	rootCmd.AddCommand(newMergeCommand())
+	rootCmd.AddCommand(newDiffCommand())
+	rootCmd.AddCommand(newHistoryCommand())
+	rootCmd.AddCommand(newBenchDiffCommand())
 	return rootCmd
 }
 
+// GenerateReports writes the processed test data in every format requested with the format flag
+func GenerateReports(processedTestdata *ProcessedTestdata) error {
+	for _, format := range reportFormats {
+		switch format {
+		case "html":
+			err := GenerateHTMLReport(processedTestdata)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating report html")
+				return err
+			}
+		case "json":
+			err := GenerateJSONReport(processedTestdata)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating report json")
+				return err
+			}
+		case "junit":
+			err := GenerateJUnitReport(processedTestdata)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating report xml")
+				return err
+			}
+		case "markdown":
+			err := GenerateMarkdownReport(processedTestdata)
+			if err != nil {
+				log.Error().Err(err).Msg("error generating report markdown")
+				return err
+			}
+		default:
+			err := fmt.Errorf("unknown report format %q", format)
+			log.Error().Err(err).Msg("error generating reports")
+			return err
+		}
+	}
+
+	if githubStepSummary {
+		err := AppendGitHubStepSummary(processedTestdata)
+		if err != nil {
+			log.Error().Err(err).Msg("error appending github step summary")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadLogsFromFiles reads and concatenates the logs of every file matched by the given paths, glob patterns and
+// directories. When more than one file is read, each event records the file it was read from
+func ReadLogsFromFiles(patterns []string) (*[]GoTestJsonRowData, error) {
+	files := make([]string, 0)
+	for _, pattern := range patterns {
+		patternFiles, err := expandLogFilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, patternFiles...)
+	}
+
+	rowData := make([]GoTestJsonRowData, 0)
+	for _, file := range files {
+		fileLogData, err := ReadLogsFromFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range *fileLogData {
+			if len(files) > 1 {
+				row.Shard = file
+			}
+			rowData = append(rowData, row)
+		}
+	}
+
+	return &rowData, nil
+}
+
+// expandLogFilePattern returns the files of a directory, the files matching a glob pattern, or the file itself
+func expandLogFilePattern(pattern string) ([]string, error) {
+	info, err := os.Stat(pattern)
+	if err == nil && info.IsDir() {
+		entries, err := ioutil.ReadDir(pattern)
+		if err != nil {
+			log.Error().Err(err).Msg("error reading log directory")
+			return nil, err
+		}
+
+		files := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if entry.Mode().IsRegular() {
+				files = append(files, filepath.Join(pattern, entry.Name()))
+			}
+		}
+		return files, nil
+	} else if err == nil {
+		return []string{pattern}, nil
+	}
+
+	files, globErr := filepath.Glob(pattern)
+	if globErr != nil {
+		log.Error().Err(globErr).Msg("error matching log file pattern")
+		return nil, globErr
+	}
+	if len(files) == 0 {
+		log.Error().Err(err).Msg("error finding log files")
+		return nil, err
+	}
+
+	return files, nil
+}
+
 func ReadLogsFromFile(fileName string) (*[]GoTestJsonRowData, error) {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -144,18 +398,18 @@ func ReadLogsFromFile(fileName string) (*[]GoTestJsonRowData, error) {
 		}
 	}()
 
+	// the time the log was last written stands in for the end of a text log run, which has no timestamps
+	fileInfo, err := file.Stat()
+	if err != nil {
+		log.Error().Err(err).Msg("error reading file info")
+		return nil, err
+	}
+
 	// file scanner
 	scanner := bufio.NewScanner(file)
-	rowData := make([]GoTestJsonRowData, 0)
+	lines := make([]string, 0)
 	for scanner.Scan() {
-		row := GoTestJsonRowData{}
-		// unmarshall each line to GoTestJsonRowData
-		err = json.Unmarshal([]byte(scanner.Text()), &row)
-		if err != nil {
-			log.Error().Err(err).Msg("error unmarshalling go test logs")
-			return nil, err
-		}
-		rowData = append(rowData, row)
+		lines = append(lines, scanner.Text())
 	}
 
 	if err = scanner.Err(); err != nil {
@@ -163,167 +417,259 @@ func ReadLogsFromFile(fileName string) (*[]GoTestJsonRowData, error) {
 		return nil, err
 	}
 
-	return &rowData, nil
+	return ParseLogLines(lines, fileInfo.ModTime())
 }
 
 func ReadLogsFromStdIn() (*[]GoTestJsonRowData, error) {
 	// stdin scanner
 	scanner := bufio.NewScanner(os.Stdin)
-	rowData := make([]GoTestJsonRowData, 0)
+	lines := make([]string, 0)
 	for scanner.Scan() {
-		row := GoTestJsonRowData{}
-		// unmarshall each line into GoTestJsonRowData
-		err := json.Unmarshal([]byte(scanner.Text()), &row)
-		if err != nil {
-			log.Error().Err(err).Msg("error unmarshalling the test logs")
-			return nil, err
-		}
-		rowData = append(rowData, row)
+		lines = append(lines, scanner.Text())
 	}
 	if err := scanner.Err(); err != nil {
 		log.Error().Err(err).Msg("error with stdin scanner")
 		return nil, err
 	}
 
-	return &rowData, nil
+	// a text log piped from go test ends when standard input is closed
+	return ParseLogLines(lines, time.Now())
 }
 
 func ProcessTestData(rowData []GoTestJsonRowData) (*ProcessedTestdata, error) {
 	packageDetailsMap := map[string]PackageDetails{}
+	buildOutputMap := map[string][]string{}
+	// the tested packages that failed to build, mapped to the package whose build failed, which is a dependency
+	// when the tested package imports a package that does not compile
+	buildFailedMap := map[string]string{}
 	for _, r := range rowData {
+		// build events carry the import path of the package being built, e.g. "example.com/pkg [example.com/pkg.test]"
+		if r.Action == "build-output" || r.Action == "build-fail" {
+			if r.Action == "build-output" {
+				packageName := buildPackageName(r.ImportPath)
+				buildOutputMap[packageName] = append(buildOutputMap[packageName], formatOutputLine(r.Output))
+			}
+			continue
+		}
+
 		if r.Test == "" {
+			packageDetails := packageDetailsMap[r.Package]
 			if r.Action == "fail" || r.Action == "pass" || r.Action == "skip" {
-				elapsedTime, timeSymbol := formatTimeDisplay(r.Elapsed)
-				packageDetailsMap[r.Package] = PackageDetails{
-					Name:        r.Package,
-					ElapsedTime: elapsedTime,
-					TimeSymbol:  timeSymbol,
-					Status:      r.Action,
-					Coverage:    packageDetailsMap[r.Package].Coverage,
+				// a package tested by several shards failed if any shard failed, and took the time of all shards
+				packageDetails.Name = r.Package
+				packageDetails.ElapsedTime, packageDetails.TimeSymbol = formatTimeDisplay(
+					elapsedSeconds(packageDetails.ElapsedTime, packageDetails.TimeSymbol) + r.Elapsed,
+				)
+				if packageDetails.Status != "fail" && (packageDetails.Status != "pass" || r.Action == "fail") {
+					packageDetails.Status = r.Action
+				}
+				if r.Shard != "" && !containsLine(packageDetails.Shards, r.Shard) {
+					packageDetails.Shards = append(packageDetails.Shards, r.Shard)
+				}
+				if r.FailedBuild != "" {
+					buildFailedMap[r.Package] = buildPackageName(r.FailedBuild)
 				}
 			}
 
-			// get package coverage data
+			// get package coverage data, once reported it is kept by the package output that follows
 			if r.Action == "output" {
-				// check if output contains coverage data
-				coverage := "-"
-				if strings.Contains(r.Output, "coverage") && strings.Contains(r.Output, "%") {
-					coverage = r.Output[strings.Index(r.Output, ":")+1 : strings.Index(r.Output, "%")+1]
+				if coverage, ok := ParseCoverageLine(r.Output); ok {
+					packageDetails.CoverageValue = coverage
+					packageDetails.Coverage = coverage.String()
 				}
-				elapsedTime, timeSymbol := formatTimeDisplay(packageDetailsMap[r.Package].ElapsedTime)
-				packageDetailsMap[r.Package] = PackageDetails{
-					Name:        packageDetailsMap[r.Package].Name,
-					ElapsedTime: elapsedTime,
-					TimeSymbol:  timeSymbol,
-					Status:      packageDetailsMap[r.Package].Status,
-					Coverage:    coverage,
+				packageDetails.Output = append(packageDetails.Output, formatOutputLine(r.Output))
+
+				// go versions before 1.24 only report a build failure in the package result line
+				if strings.HasSuffix(strings.TrimSpace(r.Output), "[build failed]") || strings.HasSuffix(strings.TrimSpace(r.Output), "[setup failed]") {
+					if _, ok := buildFailedMap[r.Package]; !ok {
+						buildFailedMap[r.Package] = r.Package
+					}
 				}
 			}
+			packageDetailsMap[r.Package] = packageDetails
 		}
 	}
 
-	testSuiteSlice := make([]TestDetails, 0)
-	testCasesSlice := make([]TestDetails, 0)
-	passedTests := 0
-	failedTests := 0
+	//
+	// attach the compiler errors to the packages that failed to build
+	//
+	buildFailed := 0
+	for packageName, failedBuild := range buildFailedMap {
+		packageDetails := packageDetailsMap[packageName]
+		packageDetails.Name = packageName
+		packageDetails.Status = "fail"
+		packageDetails.BuildFailed = true
+		packageDetails.BuildOutput = buildOutputMap[failedBuild]
+		packageDetailsMap[packageName] = packageDetails
+		buildFailed = buildFailed + 1
+	}
+
+	for packageName, packageDetails := range packageDetailsMap {
+		if packageDetails.CoverageValue.Kind == "" {
+			packageDetails.CoverageValue = CoverageValue{Kind: CoverageUnknown}
+			packageDetails.Coverage = packageDetails.CoverageValue.String()
+			packageDetailsMap[packageName] = packageDetails
+		}
+	}
+
+	benchmarksMap := parseBenchmarks(rowData)
+	for packageName, benchmarks := range benchmarksMap {
+		packageDetails := packageDetailsMap[packageName]
+		packageDetails.Name = packageName
+		packageDetails.Benchmarks = benchmarks
+		packageDetailsMap[packageName] = packageDetails
+	}
+
+	//
+	// build the test tree, every "/" separated segment of a test name is a level of subtests
+	//
+	testsMap := map[string]*TestDetails{}
+	testSummary := make([]*TestDetails, 0)
+	packageDumpMap := map[string][]string{}
 	for _, r := range rowData {
-		if r.Test != "" {
-			testNameSlice := strings.Split(r.Test, "/")
-
-			// if testNameSlice is not equal 1 then we assume we have a test case information. Record test case info
-			if len(testNameSlice) != 1 {
-				if r.Action == "fail" || r.Action == "pass" {
-					elapsedTime, timeSymbol := formatTimeDisplay(r.Elapsed)
-					testCasesSlice = append(
-						testCasesSlice,
-						TestDetails{
-							PackageName: r.Package,
-							Name:        r.Test,
-							ElapsedTime: elapsedTime,
-							TimeSymbol:  timeSymbol,
-							Status:      r.Action,
-						},
-					)
-				}
-				if r.Action == "fail" {
-					failedTests = failedTests + 1
-				} else if r.Action == "pass" {
-					passedTests = passedTests + 1
-				}
-				continue
-			}
+		// keep everything a package logged from the first panic on, whichever test the output was attributed to
+		if r.Action == "output" && (len(packageDumpMap[r.Package]) > 0 || strings.HasPrefix(r.Output, "panic:")) {
+			packageDumpMap[r.Package] = append(packageDumpMap[r.Package], formatOutputLine(r.Output))
+		}
 
-			// record test suite info
-			if r.Action == "fail" || r.Action == "pass" {
-				elapsedTime, timeSymbol := formatTimeDisplay(r.Elapsed)
-				testSuiteSlice = append(
-					testSuiteSlice,
-					TestDetails{
-						PackageName: r.Package,
-						Name:        r.Test,
-						ElapsedTime: elapsedTime,
-						TimeSymbol:  timeSymbol,
-						Status:      r.Action,
-					})
+		if r.Test == "" {
+			continue
+		}
+
+		test := addTestNode(testsMap, &testSummary, r.Package, r.Test)
+		switch r.Action {
+		case "run":
+			test.started = true
+		case "output":
+			test.Output = append(test.Output, formatOutputLine(r.Output))
+			if r.OutputType != "" {
+				test.outputTyped = true
 			}
-			if r.Action == "fail" {
-				failedTests = failedTests + 1
-			} else if r.Action == "pass" {
-				passedTests = passedTests + 1
+			if r.OutputType == "error" || r.OutputType == "error-continue" {
+				test.errorOutput = append(test.errorOutput, formatOutputLine(r.Output))
 			}
+		case "pass", "fail", "skip":
+			// repeated executions of a test are aggregated into one node, showing the mean elapsed time
+			test.Attempts = test.Attempts + 1
+			test.totalElapsed = test.totalElapsed + r.Elapsed
+			test.ElapsedTime, test.TimeSymbol = formatTimeDisplay(test.totalElapsed / float64(test.Attempts))
+			if r.Action == "pass" {
+				test.PassedAttempts = test.PassedAttempts + 1
+			} else if r.Action == "fail" {
+				test.FailedAttempts = test.FailedAttempts + 1
+			}
+			test.Status = attemptsStatus(r.Action, test.PassedAttempts, test.FailedAttempts)
 		}
 	}
 
 	//
-	// group the test cases by their test suite
+	// tests that started but never finished were cut short by a panic or by the -timeout of go test. benchmarks
+	// report no result event, they finished once a result line of them or their sub-benchmarks was parsed
 	//
-	testSummary := make([]TestOverview, 0)
-	for _, t := range testSuiteSlice {
-		testCases := make([]TestDetails, 0)
-		for _, t2 := range testCasesSlice {
-			if strings.Contains(t2.Name, t.Name) && t2.PackageName == t.PackageName {
-				testCases = append(testCases, t2)
+	passedTests := 0
+	failedTests := 0
+	skippedTests := 0
+	flakyTests := 0
+	incompleteTests := 0
+	for _, test := range testsMap {
+		if test.Status == "" && isBenchmark(test.Name) {
+			if hasBenchmarkResult(benchmarksMap[test.PackageName], test.Name) {
+				test.Status = "pass"
+			}
+		} else if test.started && test.Status == "" {
+			test.Status = unfinishedTestStatus(packageDumpMap[test.PackageName])
+			if len(packageDumpMap[test.PackageName]) > 0 && !containsLine(test.Output, packageDumpMap[test.PackageName][0]) {
+				test.Dump = packageDumpMap[test.PackageName]
 			}
 		}
-		testSummary = append(
-			testSummary,
-			TestOverview{
-				TestSuite: t,
-				TestCases: testCases,
-			},
-		)
+
+		switch test.Status {
+		case "pass":
+			passedTests = passedTests + 1
+		case "fail":
+			failedTests = failedTests + 1
+		case "skip":
+			skippedTests = skippedTests + 1
+		case "flaky":
+			flakyTests = flakyTests + 1
+		case "timeout", "panic", "incomplete":
+			incompleteTests = incompleteTests + 1
+		}
+		// without output types every file:line: message of a failed test is taken for a failure, t.Log included
+		failureOutput := test.Output
+		if test.outputTyped {
+			failureOutput = test.errorOutput
+		}
+		test.Failures = parseFailureLocations(test.Status, failureOutput)
+		test.SkipReason = parseSkipReason(test.Status, test.Output)
+		// the fuzzing engine reports to the fuzz target, its seed corpus entries are subtests
+		if test.Kind == "fuzz" && !strings.Contains(test.Name, "/") {
+			test.Fuzz = parseFuzzDetails(test.Output)
+		}
+		if test.Kind == "example" {
+			test.Example = parseExampleOutput(test.Status, test.Output)
+		}
 	}
+	testSummary = pruneUnfinishedTests(testSummary)
 
 	//
-	// determine total test time
+	// determine total test time, the wall clock time from the earliest to the latest event of all shards.
+	// build events carry no time
 	//
-	totalTestTime := ""
-	if rowData[len(rowData)-1].Time.Sub(rowData[0].Time).Seconds() < 60 {
-		totalTestTime = fmt.Sprintf("%f s", rowData[len(rowData)-1].Time.Sub(rowData[0].Time).Seconds())
-	} else {
-		min := int(math.Trunc(rowData[len(rowData)-1].Time.Sub(rowData[0].Time).Seconds() / 60))
-		seconds := int(math.Trunc((rowData[len(rowData)-1].Time.Sub(rowData[0].Time).Minutes() - float64(min)) * 60))
-		totalTestTime = fmt.Sprintf("%dm:%ds", min, seconds)
+	startTime := time.Time{}
+	endTime := time.Time{}
+	for _, r := range rowData {
+		if r.Time.IsZero() {
+			continue
+		}
+		if startTime.IsZero() || r.Time.Before(startTime) {
+			startTime = r.Time
+		}
+		if r.Time.After(endTime) {
+			endTime = r.Time
+		}
 	}
-	testDate := rowData[0].Time.Format(time.RFC850)
+
+	totalTestTime := formatTotalTestTime(startTime, endTime)
+	testDate := startTime.Format(time.RFC850)
 
 	return &ProcessedTestdata{
 		TotalTestTime:     totalTestTime,
 		TestDate:          testDate,
+		StartTime:         startTime,
+		EndTime:           endTime,
 		FailedTests:       failedTests,
 		PassedTests:       passedTests,
+		SkippedTests:      skippedTests,
+		FlakyTests:        flakyTests,
+		IncompleteTests:   incompleteTests,
+		BuildFailed:       buildFailed,
 		TestSummary:       testSummary,
 		PackageDetailsMap: packageDetailsMap,
 	}, nil
 }
 
-func GenerateHTMLReport(totalTestTime, testDate string, failedTests, passedTests int, testSummary []TestOverview, packageDetailsMap map[string]PackageDetails) error {
+func GenerateHTMLReport(processedTestdata *ProcessedTestdata) error {
+
+	testsEl, err := generateTestHTMLElements(processedTestdata.TestSummary)
+	if err != nil {
+		return err
+	}
 
-	testCasesEl, _ := generateTestCaseHTMLElements(testSummary)
+	packagesEl, err := generatePackageDetailsHTMLElements(*testsEl, processedTestdata.PackageDetailsMap)
+	if err != nil {
+		return err
+	}
 
-	testSuitesEl, _ := generateTestSuiteHTMLElements(testSummary, *testCasesEl)
+	err = GenerateSourcePages(processedTestdata.PackageDetailsMap)
+	if err != nil {
+		return err
+	}
 
-	packagesEl, _ := generatePackageDetailsHTMLElements(*testSuitesEl, packageDetailsMap)
+	benchmarksEl, err := generateBenchmarksHTMLElement(processedTestdata.PackageDetailsMap)
+	if err != nil {
+		return err
+	}
 
 	reportTemplate := template.New("report-template.html")
 	reportTemplateData, err := assets.Asset("report-template.html")
@@ -343,17 +689,36 @@ func GenerateHTMLReport(totalTestTime, testDate string, failedTests, passedTests
 		HTMLElements  []template.HTML
 		FailedTests   int
 		PassedTests   int
+		SkippedTests  int
+		FlakyTests    int
+		Incomplete    int
+		BuildFailed   int
 		TotalTestTime string
 		TestDate      string
+		TotalCoverage string
+
+		CoverageViolations []CoverageViolation
+	}
+
+	totalCoverage := ""
+	if processedTestdata.TotalCoverage != nil {
+		totalCoverage = processedTestdata.TotalCoverage.String()
 	}
 
 	err = report.Execute(&processedTemplate,
 		&templateData{
-			HTMLElements:  []template.HTML{template.HTML(packagesEl)},
-			FailedTests:   failedTests,
-			PassedTests:   passedTests,
-			TotalTestTime: totalTestTime,
-			TestDate:      testDate,
+			HTMLElements:  []template.HTML{template.HTML(packagesEl), template.HTML(benchmarksEl)},
+			FailedTests:   processedTestdata.FailedTests,
+			PassedTests:   processedTestdata.PassedTests,
+			SkippedTests:  processedTestdata.SkippedTests,
+			FlakyTests:    processedTestdata.FlakyTests,
+			Incomplete:    processedTestdata.IncompleteTests,
+			BuildFailed:   processedTestdata.BuildFailed,
+			TotalTestTime: processedTestdata.TotalTestTime,
+			TestDate:      processedTestdata.TestDate,
+			TotalCoverage: totalCoverage,
+
+			CoverageViolations: processedTestdata.CoverageViolations,
 		},
 	)
 	if err != nil {
@@ -361,15 +726,8 @@ func GenerateHTMLReport(totalTestTime, testDate string, failedTests, passedTests
 		return err
 	}
 
-	var path = ""
-	if outputDirectory == "" {
-		path = "./report.html"
-	} else {
-		path = fmt.Sprintf("%s/report.html", outputDirectory)
-	}
-
 	// write the whole body at once
-	err = ioutil.WriteFile(path, processedTemplate.Bytes(), 0644)
+	err = ioutil.WriteFile(reportPath("report.html"), processedTemplate.Bytes(), 0644)
 	if err != nil {
 		log.Error().Err(err).Msg("error writing report.html file")
 		return err
@@ -378,145 +736,215 @@ func GenerateHTMLReport(totalTestTime, testDate string, failedTests, passedTests
 	return nil
 }
 
-// generate test cases cards
-func generateTestCaseHTMLElements(testsLogOverview []TestOverview) (*map[string][]string, error) {
-	testCasesCardsMap := make(map[string][]string)
-	testCaseCard := template.HTML("")
+// sortedPackageNames returns the names of the packages in alphabetical order
+func sortedPackageNames(packageDetailsMap map[string]PackageDetails) []string {
+	packageNames := make([]string, 0, len(packageDetailsMap))
+	for packageName := range packageDetailsMap {
+		packageNames = append(packageNames, packageName)
+	}
+	sort.Strings(packageNames)
 
-	for _, testSuite := range testsLogOverview {
-		for _, testCaseDetails := range testSuite.TestCases {
-			testCaseCard = `
-										<div>{{.testName}}</div>
-										<div>{{.elapsedTime}}{{.timeSymbol}}</div>
-									`
-			testCaseTemplate, err := template.New("testCase").Parse(string(testCaseCard))
-			if err != nil {
-				log.Error().Err(err).Msg("error parsing test case template")
-				return nil, err
-			}
+	return packageNames
+}
 
-			var processedTestCaseTemplate bytes.Buffer
-			err = testCaseTemplate.Execute(&processedTestCaseTemplate, map[string]string{
-				"testName":    testCaseDetails.Name,
-				"elapsedTime": fmt.Sprintf("%f", testCaseDetails.ElapsedTime),
-				"timeSymbol":  fmt.Sprintf("%s", testCaseDetails.TimeSymbol),
-			})
+// reportPath returns the path of a report file in the output directory
+func reportPath(fileName string) string {
+	if outputDirectory == "" {
+		return fmt.Sprintf("./%s", fileName)
+	}
 
-			if err != nil {
-				log.Error().Err(err).Msg("error applying test case template")
-				return nil, err
-			}
-			if testCaseDetails.Status == "pass" {
-				testCaseCard = template.HTML(
-					fmt.Sprintf(`
-												<div class="testCardLayout successBackgroundColor">
-												%s
-												</div>
-											`,
-						template.HTML(processedTestCaseTemplate.Bytes()),
-					),
-				)
+	return fmt.Sprintf("%s/%s", outputDirectory, fileName)
+}
 
-			} else if testCaseDetails.Status == "fail" {
-				testCaseCard = template.HTML(
-					fmt.Sprintf(`
-												<div class="testCardLayout failBackgroundColor ">
-												%s
-												</div>
-												`,
-						template.HTML(processedTestCaseTemplate.Bytes()),
-					),
-				)
+// generate the test cards of every package, keyed by package name
+func generateTestHTMLElements(testSummary []*TestDetails) (*map[string][]string, error) {
+	testCardsMap := make(map[string][]string)
 
-			}
-			testCasesCardsMap[testSuite.TestSuite.Name+"-"+testSuite.TestSuite.PackageName] = append(testCasesCardsMap[testSuite.TestSuite.Name], string(testCaseCard))
+	for _, test := range testSummary {
+		testCard, err := generateTestHTMLElement(test)
+		if err != nil {
+			return nil, err
 		}
+		testCardsMap[test.PackageName] = append(testCardsMap[test.PackageName], testCard)
 	}
 
-	return &testCasesCardsMap, nil
+	return &testCardsMap, nil
 }
 
-// generate test suites cards
-func generateTestSuiteHTMLElements(testLogOverview []TestOverview, testCaseHTMLCards map[string][]string) (*map[string][]string, error) {
-	testSuiteCollapsibleCardsMap := make(map[string][]string)
-	collapsible := template.HTML("")
-	collapsibleHeading := template.HTML("")
-	collapsibleHeadingTemplate := ""
-	collapsibleContent := template.HTML("")
-
-	for _, testSuite := range testLogOverview {
-		collapsibleHeadingTemplate = `		
-										<div>{{.testName}}</div>
-										<div>{{.elapsedTime}}{{.timeSymbol}}</div>
+// generate the card of a test, tests with subtests become a collapsible holding the cards of their subtests
+func generateTestHTMLElement(test *TestDetails) (string, error) {
+	testCardTemplate := `
+										<div>{{.testName}}{{if ne .kind "test"}} <span class="kindLabel">{{.kind}}</span>{{end}}{{if .incompleteStatus}} <span class="incompleteLabel">[{{.incompleteStatus}}]</span>{{end}}</div>
+										<div>{{if gt .attempts 1}}<span class="attempts">{{.attempts}} attempts, {{.passRate}}% passed</span> {{end}}{{.elapsedTime}}{{.timeSymbol}}</div>
+										{{if .skipReason}}<div class="skipReason">Skipped: {{.skipReason}}</div>{{end}}
+										{{with .fuzz}}
+										<div class="fuzzDetails">
+											{{if .Elapsed}}<div>Fuzzed for {{.Elapsed}}: {{.Execs}} execs, {{.ExecsPerSec}} execs/sec, {{.NewInteresting}} new interesting inputs (total: {{.TotalInteresting}})</div>{{end}}
+											{{if .FailingInput}}<div>Failing input: {{if $.failingInputLink}}<a href="{{$.failingInputLink}}">{{.FailingInput}}</a>{{else}}{{.FailingInput}}{{end}}{{if .RerunCommand}}, re-run with <code>{{.RerunCommand}}</code>{{end}}</div>{{end}}
+										</div>
+										{{end}}
+										{{.failures}}
+										{{.exampleDiff}}
+										{{.output}}
+										{{.dump}}
 									`
-		testCaseTemplate, err := template.New("testSuite").Parse(collapsibleHeadingTemplate)
-		if err != nil {
-			log.Error().Err(err).Msg("error parsing test case template")
-			return nil, err
-		}
+	testTemplate, err := template.New("test").Parse(testCardTemplate)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing test template")
+		return "", err
+	}
 
-		var processedTestCaseTemplate bytes.Buffer
-		err = testCaseTemplate.Execute(&processedTestCaseTemplate, map[string]string{
-			"testName":    testSuite.TestSuite.Name,
-			"elapsedTime": fmt.Sprintf("%f", testSuite.TestSuite.ElapsedTime),
-			"timeSymbol":  fmt.Sprintf("%s", testSuite.TestSuite.TimeSymbol),
-		})
+	testOutputEl, err := generateTestOutputHTMLElement("Output", test.Output)
+	if err != nil {
+		return "", err
+	}
+
+	dumpEl, err := generateTestOutputHTMLElement("Goroutine dump", test.Dump)
+	if err != nil {
+		return "", err
+	}
+
+	incompleteStatus := ""
+	if test.Status == "timeout" {
+		incompleteStatus = "timed out"
+	} else if test.Status == "panic" {
+		incompleteStatus = "panicked"
+	} else if test.Status == "incomplete" {
+		incompleteStatus = "incomplete"
+	}
+
+	failureLocationsEl, err := generateFailureLocationsHTMLElement(test.Failures)
+	if err != nil {
+		return "", err
+	}
+
+	// the got and want blocks of a failing example are shown as a diff instead of the raw output
+	exampleDiffEl, err := generateExampleDiffHTMLElement(test.Example)
+	if err != nil {
+		return "", err
+	}
+	if test.Example != nil {
+		testOutputEl = ""
+	}
+
+	templateData := map[string]interface{}{
+		"testName":    test.Name,
+		"elapsedTime": fmt.Sprintf("%f", test.ElapsedTime),
+		"timeSymbol":  fmt.Sprintf("%s", test.TimeSymbol),
+		"skipReason":  test.SkipReason,
+		"failures":    failureLocationsEl,
+		"output":      testOutputEl,
+		"dump":        dumpEl,
+		"attempts":    test.Attempts,
+		"passRate":    passRate(test),
+		"kind":        test.Kind,
+		"fuzz":        test.Fuzz,
+		"exampleDiff": exampleDiffEl,
+
+		"incompleteStatus": incompleteStatus,
+	}
+	if test.Fuzz != nil && test.Fuzz.FailingInput != "" {
+		templateData["failingInputLink"] = failingInputLink(test.PackageName, test.Fuzz.FailingInput)
+	}
+
+	// a test without subtests is a plain card
+	if len(test.Subtests) == 0 {
+		var processedTestTemplate bytes.Buffer
+		err = testTemplate.Execute(&processedTestTemplate, templateData)
 		if err != nil {
-			log.Error().Err(err).Msg("error applying test case template")
-			return nil, err
+			log.Error().Err(err).Msg("error applying test template")
+			return "", err
 		}
 
-		if testSuite.TestSuite.Status == "pass" {
-			collapsibleHeading = template.HTML(
-				fmt.Sprintf(`
-											<div class="testCardLayout successBackgroundColor collapsibleHeading">
-											%s
-											</div>
-										`,
-					template.HTML(processedTestCaseTemplate.Bytes()),
-				),
-			)
+		return fmt.Sprintf(`
+												<div class="testCardLayout %s">
+												%s
+												</div>
+											`,
+			testStatusBackgroundColor(test.Status),
+			processedTestTemplate.String(),
+		), nil
+	}
 
-		} else if testSuite.TestSuite.Status == "fail" {
-			collapsibleHeading = template.HTML(
-				fmt.Sprintf(`
-											<div class="testCardLayout failBackgroundColor collapsibleHeading">
+	// the log pane of a test with subtests goes into the collapsible content, clicks on the heading toggle the collapsible
+	templateData["output"] = template.HTML("")
+	templateData["dump"] = template.HTML("")
+	var processedTestTemplate bytes.Buffer
+	err = testTemplate.Execute(&processedTestTemplate, templateData)
+	if err != nil {
+		log.Error().Err(err).Msg("error applying test template")
+		return "", err
+	}
+
+	collapsibleHeading := template.HTML(
+		fmt.Sprintf(`
+											<div class="testCardLayout %s collapsibleHeading">
 											%s
 											</div>
 										`,
-					template.HTML(processedTestCaseTemplate.Bytes()),
-				),
-			)
+			testStatusBackgroundColor(test.Status),
+			processedTestTemplate.String(),
+		),
+	)
+
+	subtestCards := make([]string, 0, len(test.Subtests))
+	for _, subtest := range test.Subtests {
+		subtestCard, err := generateTestHTMLElement(subtest)
+		if err != nil {
+			return "", err
 		}
+		subtestCards = append(subtestCards, subtestCard)
+	}
 
-		// construct a collapsible content
-		collapsibleContent = template.HTML(
-			fmt.Sprintf(`
+	// construct a collapsible content
+	collapsibleContent := template.HTML(
+		fmt.Sprintf(`
 									<div class="collapsibleHeadingContent">
 										%s
+										%s
+										%s
 									</div>
 							`,
-				strings.Join(testCaseHTMLCards[testSuite.TestSuite.Name+"-"+testSuite.TestSuite.PackageName], "\n"),
-			),
-		)
+			string(testOutputEl),
+			string(dumpEl),
+			strings.Join(subtestCards, "\n"),
+		),
+	)
 
-		// wrap in a collapsible
-		collapsible = template.HTML(
-			fmt.Sprintf(`
+	// wrap in a collapsible
+	return fmt.Sprintf(`
 						<div type="button" class="collapsible">
 							%s
 							%s
 						</div>
 							`,
-				string(collapsibleHeading),
-				string(collapsibleContent),
-			),
-		)
+		string(collapsibleHeading),
+		string(collapsibleContent),
+	), nil
+}
+
+// passRate returns the percentage of the attempts of a test that passed
+func passRate(test *TestDetails) int {
+	if test.Attempts == 0 {
+		return 0
+	}
 
-		testSuiteCollapsibleCardsMap[testSuite.TestSuite.PackageName] = append(testSuiteCollapsibleCardsMap[testSuite.TestSuite.PackageName], string(collapsible))
+	return int(math.Round(float64(test.PassedAttempts) * 100 / float64(test.Attempts)))
+}
+
+// testStatusBackgroundColor returns the card style of a test status
+func testStatusBackgroundColor(status string) string {
+	if status == "pass" {
+		return "successBackgroundColor"
+	} else if status == "skip" {
+		return "skipBackgroundColor"
+	} else if status == "timeout" || status == "panic" || status == "incomplete" {
+		return "incompleteBackgroundColor"
+	} else if status == "flaky" {
+		return "flakyBackgroundColor"
 	}
 
-	return &testSuiteCollapsibleCardsMap, nil
+	return "failBackgroundColor"
 }
 
 // generate package cards
@@ -529,8 +957,8 @@ func generatePackageDetailsHTMLElements(testSuiteOverview map[string][]string, p
 
 	for _, v := range packageDetailsMap {
 		collapsibleHeadingTemplate = `
-											<div>{{.packageName}}</div>
-											<div>{{.coverage}}</div>
+											<div>{{.packageName}}{{if .buildFailed}} <span class="buildFailedLabel">[build failed]</span>{{end}}{{range .shards}} <span class="shardLabel">{{.}}</span>{{end}}</div>
+											<div>{{.coverage}}{{if .belowCoverageFloor}} <span class="coverageViolationLabel">below {{.coverageFloor}}%</span>{{end}}</div>
 											<div>{{.elapsedTime}}{{.timeSymbol}}</div>
 											`
 
@@ -540,11 +968,16 @@ func generatePackageDetailsHTMLElements(testSuiteOverview map[string][]string, p
 			os.Exit(1)
 		}
 		var processedPackageTemplate bytes.Buffer
-		err = packageInfoTemplate.Execute(&processedPackageTemplate, map[string]string{
+		err = packageInfoTemplate.Execute(&processedPackageTemplate, map[string]interface{}{
 			"packageName": v.Name,
 			"elapsedTime": fmt.Sprintf("%f", v.ElapsedTime),
 			"timeSymbol":  fmt.Sprintf("%s", v.TimeSymbol),
 			"coverage":    v.Coverage,
+
+			"belowCoverageFloor": v.BelowCoverageFloor,
+			"coverageFloor":      v.CoverageFloor,
+			"buildFailed":        v.BuildFailed,
+			"shards":             v.Shards,
 		})
 		if err != nil {
 			log.Error().Err(err).Msg("error applying package info template")
@@ -588,13 +1021,27 @@ func generatePackageDetailsHTMLElements(testSuiteOverview map[string][]string, p
 			)
 		}
 
+		buildOutputEl, err := generateBuildOutputHTMLElement(v)
+		if err != nil {
+			return "", err
+		}
+
+		coverageBreakdownEl, err := generateCoverageBreakdownHTMLElement(v)
+		if err != nil {
+			return "", err
+		}
+
 		// construct a collapsible content
 		collapsibleContent = template.HTML(
 			fmt.Sprintf(`
 									<div class="collapsibleHeadingContent">
 										%s
+										%s
+										%s
 									</div>
 							`,
+				string(buildOutputEl),
+				string(coverageBreakdownEl),
 				strings.Join(testSuiteOverview[v.Name], "\n"),
 			),
 		)
@@ -618,6 +1065,332 @@ func generatePackageDetailsHTMLElements(testSuiteOverview map[string][]string, p
 	return strings.Join(elem, "\n"), nil
 }
 
+// generate the compiler errors shown on the card of a package that failed to build
+func generateBuildOutputHTMLElement(packageDetails PackageDetails) (template.HTML, error) {
+	if !packageDetails.BuildFailed {
+		return "", nil
+	}
+
+	buildOutputTemplate, err := template.New("buildOutput").Parse(`
+										<div class="buildOutput">
+											<div>Build failed</div>
+											{{if .}}<pre>{{range .}}{{.}}
+{{end}}</pre>{{end}}
+										</div>
+									`)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing build output template")
+		return "", err
+	}
+
+	var processedBuildOutputTemplate bytes.Buffer
+	err = buildOutputTemplate.Execute(&processedBuildOutputTemplate, packageDetails.BuildOutput)
+	if err != nil {
+		log.Error().Err(err).Msg("error applying build output template")
+		return "", err
+	}
+
+	return template.HTML(processedBuildOutputTemplate.String()), nil
+}
+
+// generate an expandable log pane holding the output lines of a test
+func generateTestOutputHTMLElement(summary string, output []string) (template.HTML, error) {
+	if len(output) == 0 {
+		return "", nil
+	}
+
+	testOutputTemplate, err := template.New("testOutput").Parse(`
+										<details class="testOutput">
+											<summary>{{.summary}} ({{len .output}} lines)</summary>
+											<pre>{{range .output}}{{.}}
+{{end}}</pre>
+										</details>
+									`)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing test output template")
+		return "", err
+	}
+
+	var processedTestOutputTemplate bytes.Buffer
+	err = testOutputTemplate.Execute(&processedTestOutputTemplate, map[string]interface{}{
+		"summary": summary,
+		"output":  output,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error applying test output template")
+		return "", err
+	}
+
+	return template.HTML(processedTestOutputTemplate.String()), nil
+}
+
+// generate the list of failure locations shown at the top of a failing test card
+func generateFailureLocationsHTMLElement(failures []FailureLocation) (template.HTML, error) {
+	if len(failures) == 0 {
+		return "", nil
+	}
+
+	failureLocationsTemplate, err := template.New("failureLocations").Parse(`
+										<div class="failureLocations">
+											{{range .}}
+											<div class="failureLocation">
+												<span class="failureLocationFile">{{.File}}:{{.Line}}</span>
+												<pre>{{.Message}}</pre>
+											</div>
+											{{end}}
+										</div>
+									`)
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing failure locations template")
+		return "", err
+	}
+
+	var processedFailureLocationsTemplate bytes.Buffer
+	err = failureLocationsTemplate.Execute(&processedFailureLocationsTemplate, failures)
+	if err != nil {
+		log.Error().Err(err).Msg("error applying failure locations template")
+		return "", err
+	}
+
+	return template.HTML(processedFailureLocationsTemplate.String()), nil
+}
+
+// matches the "    foo_test.go:42: message" prefix written by t.Log, t.Errorf, t.Fatalf etc.
+var failureLocationPrefix = regexp.MustCompile(`^(\s+)([^\s:]+\.go):(\d+): ?(.*)$`)
+
+// parseFailureLocations extracts the file, line and message of every failure reported in the output of a failed test.
+func parseFailureLocations(status string, output []string) []FailureLocation {
+	if status != "fail" && status != "flaky" {
+		return nil
+	}
+
+	return parseOutputLocations(output)
+}
+
+// parseSkipReason returns the message passed to t.Skip, which is the last message logged by a skipped test
+func parseSkipReason(status string, output []string) string {
+	if status != "skip" {
+		return ""
+	}
+
+	locations := parseOutputLocations(output)
+	if len(locations) == 0 {
+		return ""
+	}
+
+	return locations[len(locations)-1].Message
+}
+
+// parseOutputLocations extracts every message logged with a "file:line:" prefix from the output of a test.
+// Messages spanning several lines are joined, and testify "Error Trace:"/"Error:" blocks are reduced to the
+// location and message they describe.
+func parseOutputLocations(output []string) []FailureLocation {
+	failures := make([]FailureLocation, 0)
+	var indentation string
+	var continuation []string
+	inMessage := false
+	flush := func() {
+		if !inMessage {
+			return
+		}
+		inMessage = false
+		last := &failures[len(failures)-1]
+		if !applyTestifyBlock(last, continuation) && len(continuation) > 0 {
+			lines := []string{last.Message}
+			for _, c := range continuation {
+				lines = append(lines, strings.TrimPrefix(c, indentation+"    "))
+			}
+			last.Message = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		continuation = nil
+	}
+
+	for _, line := range output {
+		if match := failureLocationPrefix.FindStringSubmatch(line); match != nil {
+			flush()
+			lineNumber, err := strconv.Atoi(match[3])
+			if err != nil {
+				continue
+			}
+			indentation = match[1]
+			inMessage = true
+			failures = append(failures, FailureLocation{
+				File:    match[2],
+				Line:    lineNumber,
+				Message: match[4],
+			})
+			continue
+		}
+
+		// lines indented deeper than the location prefix continue the current message
+		if inMessage && strings.HasPrefix(line, indentation) && len(line) > len(indentation) &&
+			(line[len(indentation)] == ' ' || line[len(indentation)] == '\t') {
+			continuation = append(continuation, line)
+			continue
+		}
+		flush()
+		indentation = ""
+	}
+	flush()
+
+	return failures
+}
+
+// applyTestifyBlock rewrites a failure location from a testify assertion block, e.g.
+//
+//	foo_test.go:42:
+//	    	Error Trace:	/src/foo_test.go:42
+//	    	Error:      	Not equal:
+//	    	            	expected: 1
+//	    	Test:       	TestFoo
+//
+// It reports whether the lines formed such a block.
+func applyTestifyBlock(failure *FailureLocation, lines []string) bool {
+	key := ""
+	fields := map[string][]string{}
+	for _, line := range lines {
+		parts := strings.SplitN(strings.TrimLeft(line, " "), "\t", 3)
+		if len(parts) != 3 || parts[0] != "" {
+			return false
+		}
+		if k := strings.TrimSpace(parts[1]); k != "" {
+			key = strings.TrimSuffix(k, ":")
+		}
+		if key == "" {
+			return false
+		}
+		fields[key] = append(fields[key], parts[2])
+	}
+
+	if len(fields["Error Trace"]) == 0 || len(fields["Error"]) == 0 {
+		return false
+	}
+
+	trace := strings.TrimSpace(fields["Error Trace"][0])
+	if separator := strings.LastIndex(trace, ":"); separator != -1 {
+		if lineNumber, err := strconv.Atoi(trace[separator+1:]); err == nil {
+			failure.File = trace[:separator]
+			failure.Line = lineNumber
+		}
+	}
+
+	message := strings.Join(fields["Error"], "\n")
+	if len(fields["Messages"]) > 0 {
+		message = message + "\n" + strings.Join(fields["Messages"], "\n")
+	}
+	failure.Message = strings.TrimSpace(message)
+
+	return true
+}
+
+var ansiEscapeSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// strip the trailing newline and any ANSI escape sequences from a go test output line
+func formatOutputLine(output string) string {
+	return ansiEscapeSequence.ReplaceAllString(strings.TrimSuffix(output, "\n"), "")
+}
+
+// buildPackageName returns the package of a build event import path, dropping the name of the test binary
+func buildPackageName(importPath string) string {
+	if separator := strings.Index(importPath, " ["); separator != -1 {
+		return importPath[:separator]
+	}
+
+	return importPath
+}
+
+// addTestNode returns the node of a test in the test tree, creating it and any missing parents on first sight
+func addTestNode(testsMap map[string]*TestDetails, testSummary *[]*TestDetails, packageName, testName string) *TestDetails {
+	key := packageName + "-" + testName
+	if test, ok := testsMap[key]; ok {
+		return test
+	}
+
+	test := &TestDetails{
+		PackageName: packageName,
+		Name:        testName,
+		Kind:        testKind(testName),
+	}
+	testsMap[key] = test
+
+	if separator := strings.LastIndex(testName, "/"); separator != -1 {
+		parent := addTestNode(testsMap, testSummary, packageName, testName[:separator])
+		parent.Subtests = append(parent.Subtests, test)
+	} else {
+		*testSummary = append(*testSummary, test)
+	}
+
+	return test
+}
+
+// pruneUnfinishedTests drops the tests that never reported a result nor started running
+func pruneUnfinishedTests(tests []*TestDetails) []*TestDetails {
+	finishedTests := make([]*TestDetails, 0, len(tests))
+	for _, test := range tests {
+		test.Subtests = pruneUnfinishedTests(test.Subtests)
+		if test.Status != "" {
+			finishedTests = append(finishedTests, test)
+		}
+	}
+
+	return finishedTests
+}
+
+func isBenchmark(testName string) bool {
+	return strings.HasPrefix(testName, "Benchmark")
+}
+
+// attemptsStatus returns the status of a test from its latest result and the results of all its attempts,
+// a test that both passed and failed is flaky
+func attemptsStatus(latestResult string, passedAttempts, failedAttempts int) string {
+	if passedAttempts > 0 && failedAttempts > 0 {
+		return "flaky"
+	}
+
+	return latestResult
+}
+
+// unfinishedTestStatus returns why the tests of a package never finished, given what it logged from its first panic on
+func unfinishedTestStatus(dump []string) string {
+	if len(dump) == 0 {
+		return "incomplete"
+	} else if strings.HasPrefix(dump[0], "panic: test timed out after") {
+		return "timeout"
+	}
+
+	return "panic"
+}
+
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatTotalTestTime formats the wall clock time of a test run
+func formatTotalTestTime(startTime, endTime time.Time) string {
+	if endTime.Sub(startTime).Seconds() < 60 {
+		return fmt.Sprintf("%f s", endTime.Sub(startTime).Seconds())
+	}
+
+	min := int(math.Trunc(endTime.Sub(startTime).Seconds() / 60))
+	seconds := int(math.Trunc((endTime.Sub(startTime).Minutes() - float64(min)) * 60))
+	return fmt.Sprintf("%dm:%ds", min, seconds)
+}
+
+// elapsedSeconds converts an elapsed time formatted by formatTimeDisplay back to seconds
+func elapsedSeconds(elapsedTime float64, timeSymbol string) float64 {
+	if timeSymbol == "ms" {
+		return elapsedTime / 1000
+	}
+
+	return elapsedTime
+}
+
 func formatTimeDisplay(secs float64) (float64, string) {
 	if secs > 1 {
 		return secs, "s"
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"math"
+	"sort"
+	"time"
+)
+
+func newMergeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge [report.json files, glob patterns or directories]",
+		Short: "merge combines report.json files of several test runs into one report",
+		Long: "merge combines report.json files generated with --format json, e.g. by the shards of a CI pipeline, " +
+			"into one summary and one report in the formats set with --format",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ValidateFailOnPolicies(failOnPolicies)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			coverageThresholds, err := ReadCoverageThresholds(coverageThresholdsFile)
+			if err != nil {
+				return err
+			}
+
+			reports := make([]*ProcessedTestdata, 0)
+			for _, pattern := range args {
+				files, err := expandLogFilePattern(pattern)
+				if err != nil {
+					return err
+				}
+
+				for _, file := range files {
+					report, err := ReadJSONReport(file)
+					if err != nil {
+						return err
+					}
+
+					// packages of reports generated from a single log are attributed to the report file
+					for packageName, packageDetails := range report.PackageDetailsMap {
+						if len(packageDetails.Shards) == 0 {
+							packageDetails.Shards = []string{file}
+							report.PackageDetailsMap[packageName] = packageDetails
+						}
+					}
+					reports = append(reports, report)
+				}
+			}
+
+			processedTestdata := MergeProcessedTestdata(reports)
+			ApplyCoverageThresholds(processedTestdata, coverageThresholds)
+
+			err = GenerateReports(processedTestdata)
+			if err != nil {
+				return err
+			}
+
+			log.Info().Msgf("Merged report generated successfully")
+			return CheckFailOnPolicies(processedTestdata)
+		},
+	}
+}
+
+// MergeProcessedTestdata combines the processed test data of several test runs. Packages and tests present in
+// more than one run are aggregated the same way as the packages of sharded logs, and the total test time spans
+// from the earliest start to the latest end of all runs.
+func MergeProcessedTestdata(reports []*ProcessedTestdata) *ProcessedTestdata {
+	merged := &ProcessedTestdata{
+		TestSummary:       make([]*TestDetails, 0),
+		PackageDetailsMap: map[string]PackageDetails{},
+	}
+
+	testsMap := map[string]*TestDetails{}
+	for _, report := range reports {
+		if !report.StartTime.IsZero() && (merged.StartTime.IsZero() || report.StartTime.Before(merged.StartTime)) {
+			merged.StartTime = report.StartTime
+		}
+		if report.EndTime.After(merged.EndTime) {
+			merged.EndTime = report.EndTime
+		}
+
+		for packageName, packageDetails := range report.PackageDetailsMap {
+			merged.PackageDetailsMap[packageName] = mergePackageDetails(merged.PackageDetailsMap[packageName], packageDetails)
+		}
+
+		for _, test := range report.TestSummary {
+			merged.TestSummary = mergeTestDetails(testsMap, merged.TestSummary, test)
+		}
+	}
+
+	for _, packageDetails := range merged.PackageDetailsMap {
+		if packageDetails.BuildFailed {
+			merged.BuildFailed = merged.BuildFailed + 1
+		}
+	}
+	countMergedTests(merged, merged.TestSummary)
+	merged.TotalCoverage = mergeTotalCoverage(merged, reports)
+
+	merged.TotalTestTime = formatTotalTestTime(merged.StartTime, merged.EndTime)
+	merged.TestDate = merged.StartTime.Format(time.RFC850)
+
+	return merged
+}
+
+// mergeTotalCoverage returns the total coverage of the merged packages when the total coverage of every run came from
+// its cover profiles, or else combines the total coverage of the runs weighted by their statements, which is only
+// possible when every run knows its statements, not with the total line of go tool cover -func, or a single run has
+// a total coverage at all
+func mergeTotalCoverage(merged *ProcessedTestdata, reports []*ProcessedTestdata) *TotalCoverage {
+	totalCoverages := make([]*TotalCoverage, 0)
+	fromCoverProfiles := true
+	for _, report := range reports {
+		if report.TotalCoverage != nil {
+			totalCoverages = append(totalCoverages, report.TotalCoverage)
+			fromCoverProfiles = fromCoverProfiles && totalStatementCoverage(report.PackageDetailsMap) != nil
+		}
+	}
+	if len(totalCoverages) == 0 {
+		return nil
+	} else if fromCoverProfiles {
+		return totalStatementCoverage(merged.PackageDetailsMap)
+	} else if len(totalCoverages) == 1 {
+		return totalCoverages[0]
+	}
+
+	totalCoverage := &TotalCoverage{}
+	for _, reportCoverage := range totalCoverages {
+		if reportCoverage.NotMergeable || reportCoverage.Statements == 0 {
+			log.Warn().Msg("the total coverage of a report read from go tool cover -func has no statements to merge, leaving it out")
+			return nil
+		}
+		totalCoverage.Statements = totalCoverage.Statements + reportCoverage.Statements
+		totalCoverage.CoveredStatements = totalCoverage.CoveredStatements + reportCoverage.CoveredStatements
+	}
+	totalCoverage.Percent = coveragePercent(totalCoverage.Statements, totalCoverage.CoveredStatements)
+
+	return totalCoverage
+}
+
+// mergePackageDetails adds the details of a package from another run to the details merged so far
+func mergePackageDetails(merged, packageDetails PackageDetails) PackageDetails {
+	if merged.Name == "" {
+		return packageDetails
+	}
+
+	merged.ElapsedTime, merged.TimeSymbol = formatTimeDisplay(
+		elapsedSeconds(merged.ElapsedTime, merged.TimeSymbol) + elapsedSeconds(packageDetails.ElapsedTime, packageDetails.TimeSymbol),
+	)
+	merged.Status = mergeStatus(merged.Status, packageDetails.Status)
+	if merged.CoverageValue.Kind == "" || merged.CoverageValue.Kind == CoverageUnknown {
+		merged.CoverageValue = packageDetails.CoverageValue
+		merged.Coverage = packageDetails.Coverage
+	}
+	merged.Output = append(merged.Output, packageDetails.Output...)
+	merged.BuildFailed = merged.BuildFailed || packageDetails.BuildFailed
+	merged.BuildOutput = append(merged.BuildOutput, packageDetails.BuildOutput...)
+	merged.Benchmarks = append(merged.Benchmarks, packageDetails.Benchmarks...)
+	if len(packageDetails.Files) > 0 {
+		merged.Files = mergeFileCoverage(merged.Files, packageDetails.Files)
+		merged.Statements = 0
+		merged.CoveredStatements = 0
+		for _, fileCoverage := range merged.Files {
+			merged.Statements = merged.Statements + fileCoverage.Statements
+			merged.CoveredStatements = merged.CoveredStatements + fileCoverage.CoveredStatements
+		}
+		merged.CoverageValue = CoverageValue{
+			Kind:    CoveragePercent,
+			Percent: coveragePercent(merged.Statements, merged.CoveredStatements),
+		}
+		merged.Coverage = merged.CoverageValue.String()
+	}
+	for _, shard := range packageDetails.Shards {
+		if !containsLine(merged.Shards, shard) {
+			merged.Shards = append(merged.Shards, shard)
+		}
+	}
+
+	return merged
+}
+
+// mergeFileCoverage adds the file coverage of a package from another run to the file coverage merged so far.
+// Statements are not summed, every run that tested a package counted the same files. The runs may have covered
+// different blocks, which reports do not keep, so a file or function counts with the most statements any run covered
+func mergeFileCoverage(merged, files []FileCoverage) []FileCoverage {
+	mergedFiles := make([]FileCoverage, 0, len(merged)+len(files))
+	fileIndexes := map[string]int{}
+	for _, fileCoverage := range append(append([]FileCoverage{}, merged...), files...) {
+		i, ok := fileIndexes[fileCoverage.FileName]
+		if !ok {
+			fileIndexes[fileCoverage.FileName] = len(mergedFiles)
+			fileCoverage.Functions = append([]FunctionCoverage{}, fileCoverage.Functions...)
+			mergedFiles = append(mergedFiles, fileCoverage)
+			continue
+		}
+
+		mergedFile := &mergedFiles[i]
+		if fileCoverage.CoveredStatements > mergedFile.CoveredStatements {
+			mergedFile.CoveredStatements = fileCoverage.CoveredStatements
+			mergedFile.Coverage = coveragePercent(mergedFile.Statements, mergedFile.CoveredStatements)
+		}
+		for _, functionCoverage := range fileCoverage.Functions {
+			for f := range mergedFile.Functions {
+				mergedFunction := &mergedFile.Functions[f]
+				if mergedFunction.Name == functionCoverage.Name && mergedFunction.Line == functionCoverage.Line &&
+					functionCoverage.CoveredStatements > mergedFunction.CoveredStatements {
+					mergedFunction.CoveredStatements = functionCoverage.CoveredStatements
+					mergedFunction.Coverage = coveragePercent(mergedFunction.Statements, mergedFunction.CoveredStatements)
+				}
+			}
+		}
+	}
+	sort.Slice(mergedFiles, func(i, j int) bool {
+		return mergedFiles[i].FileName < mergedFiles[j].FileName
+	})
+
+	return mergedFiles
+}
+
+// mergeTestDetails adds a test and its subtests to the merged test tree
+func mergeTestDetails(testsMap map[string]*TestDetails, tests []*TestDetails, test *TestDetails) []*TestDetails {
+	key := test.PackageName + "-" + test.Name
+	merged, ok := testsMap[key]
+	if !ok {
+		merged = &TestDetails{
+			PackageName: test.PackageName,
+			Name:        test.Name,
+			ElapsedTime: test.ElapsedTime,
+			TimeSymbol:  test.TimeSymbol,
+			Status:      test.Status,
+			Output:      test.Output,
+			Failures:    test.Failures,
+			SkipReason:  test.SkipReason,
+			Dump:        test.Dump,
+			Kind:        test.Kind,
+			Fuzz:        test.Fuzz,
+			Example:     test.Example,
+
+			Attempts:       test.Attempts,
+			PassedAttempts: test.PassedAttempts,
+			FailedAttempts: test.FailedAttempts,
+		}
+		testsMap[key] = merged
+		tests = append(tests, merged)
+	} else {
+		// elapsed times are means over the attempts of a run, a test that never finished counts as one attempt
+		mergedWeight := math.Max(float64(merged.Attempts), 1)
+		testWeight := math.Max(float64(test.Attempts), 1)
+		merged.ElapsedTime, merged.TimeSymbol = formatTimeDisplay(
+			(elapsedSeconds(merged.ElapsedTime, merged.TimeSymbol)*mergedWeight + elapsedSeconds(test.ElapsedTime, test.TimeSymbol)*testWeight) /
+				(mergedWeight + testWeight),
+		)
+		merged.Attempts = merged.Attempts + test.Attempts
+		merged.PassedAttempts = merged.PassedAttempts + test.PassedAttempts
+		merged.FailedAttempts = merged.FailedAttempts + test.FailedAttempts
+		merged.Status = mergeStatus(merged.Status, test.Status)
+		if merged.Status == "pass" || merged.Status == "fail" {
+			// a test that failed in one run and passed in another is flaky
+			merged.Status = attemptsStatus(merged.Status, merged.PassedAttempts, merged.FailedAttempts)
+		}
+		merged.Output = append(merged.Output, test.Output...)
+		merged.Failures = append(merged.Failures, test.Failures...)
+		merged.Dump = append(merged.Dump, test.Dump...)
+		if merged.SkipReason == "" {
+			merged.SkipReason = test.SkipReason
+		}
+	}
+
+	for _, subtest := range test.Subtests {
+		merged.Subtests = mergeTestDetails(testsMap, merged.Subtests, subtest)
+	}
+
+	return tests
+}
+
+// mergeStatus returns the status of a package or test seen in two runs, a failure in either run wins over a pass,
+// which wins over a skip
+func mergeStatus(status, other string) string {
+	if status == "" || status == "skip" || (status == "pass" && other != "skip") {
+		return other
+	}
+
+	return status
+}
+
+// countMergedTests counts the merged tests and subtests by status
+func countMergedTests(merged *ProcessedTestdata, tests []*TestDetails) {
+	for _, test := range tests {
+		switch test.Status {
+		case "pass":
+			merged.PassedTests = merged.PassedTests + 1
+		case "fail":
+			merged.FailedTests = merged.FailedTests + 1
+		case "skip":
+			merged.SkippedTests = merged.SkippedTests + 1
+		case "flaky":
+			merged.FlakyTests = merged.FlakyTests + 1
+		case "timeout", "panic", "incomplete":
+			merged.IncompleteTests = merged.IncompleteTests + 1
+		}
+		countMergedTests(merged, test.Subtests)
+	}
+}
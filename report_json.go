@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+)
+
+// JSONReportSchemaVersion is the version of the report.json schema. It is incremented whenever a field is
+// removed or changes meaning, adding fields keeps the version.
+const JSONReportSchemaVersion = 1
+
+// JSONReport is the document written to report.json. It holds the same aggregated data as the html report:
+//
+//	schemaVersion  version of this schema, see JSONReportSchemaVersion
+//	totalTestTime  wall clock time of the test run, e.g. "1.204000 s" or "2m:13s"
+//	testDate       time of the first test event in RFC850 format
+//	passedTests    number of passed tests and subtests, likewise failedTests and skippedTests
+//	flakyTests     number of tests that both passed and failed over repeated attempts
+//	incompleteTests  number of tests that panicked, timed out or never finished
+//	buildFailedPackages  number of packages that failed to build
+//	coverageViolations  packages below their coverage floor: package, coverage and floor
+//	totalCoverage  statement coverage of the whole run with --coverprofile or --cover-func: percent, statements and
+//	               coveredStatements, or notMergeable instead of the counts when read from the total line of --cover-func
+//	packages       package details keyed by import path: name, status, coverage, coverageValue (kind: percent,
+//	               no-statements or unknown, percent and scope of -coverpkg), elapsedTime, timeSymbol,
+//	               output, buildFailed, buildOutput holding the compiler errors, benchmarks: name, procs,
+//	               iterations, nsPerOp, bytesPerOp, allocsPerOp and metrics holding custom metrics by unit, and
+//	               with --coverprofile statements, coveredStatements and files: fileName, statements,
+//	               coveredStatements, coverage and functions holding the same fields with name and line,
+//	               coverageFloor and belowCoverageFloor
+//	tests          tree of top level tests: package, name, status, elapsedTime, timeSymbol, output,
+//	               failures (file, line, message), skipReason, dump, attempts, passedAttempts, failedAttempts,
+//	               kind (test, benchmark, fuzz or example), fuzz holding the fuzzing statistics and failing input of
+//	               a fuzz target, example holding the got and want output of a failing example and subtests holding
+//	               the same fields.
+//	               status is one of pass, fail, skip, flaky, panic, timeout or incomplete
+//
+// Elapsed times are expressed in the unit named by the timeSymbol next to them, either "ms" or "s".
+type JSONReport struct {
+	SchemaVersion int `json:"schemaVersion"`
+	*ProcessedTestdata
+}
+
+// GenerateJSONReport writes the processed test data to report.json in the output directory
+func GenerateJSONReport(processedTestdata *ProcessedTestdata) error {
+	reportData, err := json.MarshalIndent(
+		&JSONReport{
+			SchemaVersion:     JSONReportSchemaVersion,
+			ProcessedTestdata: processedTestdata,
+		},
+		"",
+		"  ",
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error marshalling report json")
+		return err
+	}
+
+	err = ioutil.WriteFile(reportPath("report.json"), reportData, 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error writing report.json file")
+		return err
+	}
+
+	return nil
+}
+
+// ReadTestRun reads the processed test data of a test run from either a report.json file or a go test json log
+func ReadTestRun(fileName string) (*ProcessedTestdata, error) {
+	fileData, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		log.Error().Err(err).Msg("error reading test run")
+		return nil, err
+	}
+
+	// a report.json is a single object holding a schema version, a log holds one object per line
+	fields := map[string]json.RawMessage{}
+	if json.Unmarshal(fileData, &fields) == nil {
+		if _, ok := fields["schemaVersion"]; ok {
+			return ReadJSONReport(fileName)
+		}
+	}
+
+	rowData, err := ReadLogsFromFiles([]string{fileName})
+	if err != nil {
+		return nil, err
+	}
+
+	return ProcessTestData(*rowData)
+}
+
+// ReadJSONReport reads the processed test data back from a report.json file
+func ReadJSONReport(fileName string) (*ProcessedTestdata, error) {
+	reportData, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		log.Error().Err(err).Msg("error reading report json")
+		return nil, err
+	}
+
+	report := JSONReport{}
+	err = json.Unmarshal(reportData, &report)
+	if err != nil {
+		log.Error().Err(err).Msg("error unmarshalling report json")
+		return nil, err
+	}
+
+	if report.SchemaVersion != JSONReportSchemaVersion || report.ProcessedTestdata == nil {
+		err = fmt.Errorf("%s has schema version %d, expected %d", fileName, report.SchemaVersion, JSONReportSchemaVersion)
+		log.Error().Err(err).Msg("error reading report json")
+		return nil, err
+	}
+
+	return report.ProcessedTestdata, nil
+}
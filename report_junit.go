@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"strings"
+)
+
+type JUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *JUnitProperties `xml:"properties"`
+	TestSuites []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *JUnitProperties `xml:"properties"`
+	TestCases  []JUnitTestCase  `xml:"testcase"`
+	SystemOut  *JUnitOutput     `xml:"system-out"`
+}
+
+type JUnitProperties struct {
+	Properties []JUnitProperty `xml:"property"`
+}
+
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Error     *JUnitFailure `xml:"error,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	// failed attempts of a test that eventually passed, as reported by the maven surefire rerun extension
+	FlakyFailures []JUnitFailure `xml:"flakyFailure,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",cdata"`
+}
+
+type JUnitOutput struct {
+	Contents string `xml:",cdata"`
+}
+
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// GenerateJUnitReport writes the processed test data to report.xml in the output directory,
+// with a testsuite per package and a testcase per test and subtest
+func GenerateJUnitReport(processedTestdata *ProcessedTestdata) error {
+	testCasesMap := make(map[string][]JUnitTestCase)
+	for _, test := range processedTestdata.TestSummary {
+		testCasesMap[test.PackageName] = appendJUnitTestCases(testCasesMap[test.PackageName], test)
+	}
+
+	packageNames := sortedPackageNames(processedTestdata.PackageDetailsMap)
+
+	testSuites := JUnitTestSuites{}
+	totalTime := 0.0
+	for _, packageName := range packageNames {
+		packageDetails := processedTestdata.PackageDetailsMap[packageName]
+		testSuite := JUnitTestSuite{
+			Name:      packageName,
+			Time:      formatJUnitTime(elapsedSeconds(packageDetails.ElapsedTime, packageDetails.TimeSymbol)),
+			TestCases: testCasesMap[packageName],
+		}
+		// a package that failed to build has no tests, its compiler errors are reported as an error
+		if packageDetails.BuildFailed {
+			testSuite.TestCases = append(testSuite.TestCases, JUnitTestCase{
+				Name:      "[build failed]",
+				Classname: packageName,
+				Time:      formatJUnitTime(0),
+				Error: &JUnitFailure{
+					Message:  "build failed",
+					Type:     "build",
+					Contents: strings.Join(packageDetails.BuildOutput, "\n"),
+				},
+			})
+		}
+		if len(packageDetails.Output) > 0 {
+			testSuite.SystemOut = &JUnitOutput{
+				Contents: strings.Join(packageDetails.Output, "\n"),
+			}
+		}
+		if packageDetails.Coverage != "" && packageDetails.Coverage != "-" {
+			testSuite.Properties = &JUnitProperties{
+				Properties: []JUnitProperty{
+					{
+						Name:  "coverage",
+						Value: strings.TrimSpace(packageDetails.Coverage),
+					},
+				},
+			}
+		}
+		for _, testCase := range testSuite.TestCases {
+			testSuite.Tests = testSuite.Tests + 1
+			if testCase.Failure != nil {
+				testSuite.Failures = testSuite.Failures + 1
+			} else if testCase.Error != nil {
+				testSuite.Errors = testSuite.Errors + 1
+			} else if testCase.Skipped != nil {
+				testSuite.Skipped = testSuite.Skipped + 1
+			}
+		}
+
+		testSuites.Tests = testSuites.Tests + testSuite.Tests
+		testSuites.Failures = testSuites.Failures + testSuite.Failures
+		testSuites.Errors = testSuites.Errors + testSuite.Errors
+		testSuites.Skipped = testSuites.Skipped + testSuite.Skipped
+		totalTime = totalTime + elapsedSeconds(packageDetails.ElapsedTime, packageDetails.TimeSymbol)
+		testSuites.TestSuites = append(testSuites.TestSuites, testSuite)
+	}
+	testSuites.Time = formatJUnitTime(totalTime)
+	if processedTestdata.TotalCoverage != nil {
+		testSuites.Properties = &JUnitProperties{
+			Properties: []JUnitProperty{
+				{
+					Name:  "coverage",
+					Value: processedTestdata.TotalCoverage.String(),
+				},
+			},
+		}
+		if processedTestdata.TotalCoverage.Statements > 0 {
+			testSuites.Properties.Properties = append(testSuites.Properties.Properties,
+				JUnitProperty{Name: "statements", Value: fmt.Sprint(processedTestdata.TotalCoverage.Statements)},
+				JUnitProperty{Name: "coveredStatements", Value: fmt.Sprint(processedTestdata.TotalCoverage.CoveredStatements)},
+			)
+		}
+	}
+
+	reportData, err := xml.MarshalIndent(&testSuites, "", "  ")
+	if err != nil {
+		log.Error().Err(err).Msg("error marshalling report xml")
+		return err
+	}
+
+	err = ioutil.WriteFile(reportPath("report.xml"), append([]byte(xml.Header), reportData...), 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error writing report.xml file")
+		return err
+	}
+
+	return nil
+}
+
+// appendJUnitTestCases appends the testcase of a test followed by the testcases of its subtests
+func appendJUnitTestCases(testCases []JUnitTestCase, test *TestDetails) []JUnitTestCase {
+	testCase := JUnitTestCase{
+		Name:      test.Name,
+		Classname: test.PackageName,
+		Time:      formatJUnitTime(elapsedSeconds(test.ElapsedTime, test.TimeSymbol)),
+	}
+	message := "failed"
+	if len(test.Failures) > 0 {
+		message = fmt.Sprintf("%s:%d: %s", test.Failures[0].File, test.Failures[0].Line, test.Failures[0].Message)
+	}
+	if test.Status == "fail" {
+		testCase.Failure = &JUnitFailure{
+			Message:  message,
+			Type:     "failure",
+			Contents: strings.Join(test.Output, "\n"),
+		}
+	} else if test.Status == "flaky" {
+		testCase.FlakyFailures = []JUnitFailure{
+			{
+				Message:  fmt.Sprintf("%s (%d of %d attempts failed)", message, test.FailedAttempts, test.Attempts),
+				Type:     "flaky",
+				Contents: strings.Join(test.Output, "\n"),
+			},
+		}
+	} else if test.Status == "timeout" || test.Status == "panic" || test.Status == "incomplete" {
+		testCase.Error = &JUnitFailure{
+			Message:  test.Status,
+			Type:     test.Status,
+			Contents: strings.Join(append(test.Output, test.Dump...), "\n"),
+		}
+	} else if test.Status == "skip" {
+		testCase.Skipped = &JUnitSkipped{
+			Message: test.SkipReason,
+		}
+	}
+
+	testCases = append(testCases, testCase)
+	for _, subtest := range test.Subtests {
+		testCases = appendJUnitTestCases(testCases, subtest)
+	}
+
+	return testCases
+}
+
+func formatJUnitTime(secs float64) string {
+	return fmt.Sprintf("%.3f", secs)
+}
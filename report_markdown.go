@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// number of output lines shown for a failing test that reported no failure location
+const markdownOutputExcerptLines = 20
+
+type markdownPackageCounts struct {
+	passed     int
+	failed     int
+	skipped    int
+	flaky      int
+	incomplete int
+}
+
+// GenerateMarkdownReport writes the markdown summary of the processed test data to report.md in the output directory
+func GenerateMarkdownReport(processedTestdata *ProcessedTestdata) error {
+	err := ioutil.WriteFile(reportPath("report.md"), []byte(generateMarkdownSummary(processedTestdata)), 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error writing report.md file")
+		return err
+	}
+
+	return nil
+}
+
+// AppendGitHubStepSummary appends the markdown summary of the processed test data to the file named by
+// $GITHUB_STEP_SUMMARY, which GitHub Actions renders on the summary page of a workflow run
+func AppendGitHubStepSummary(processedTestdata *ProcessedTestdata) error {
+	stepSummaryPath := os.Getenv("GITHUB_STEP_SUMMARY")
+	if stepSummaryPath == "" {
+		log.Warn().Msg("GITHUB_STEP_SUMMARY is not set, skipping the github step summary")
+		return nil
+	}
+
+	stepSummary, err := os.OpenFile(stepSummaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("error opening github step summary file")
+		return err
+	}
+	defer func() {
+		err := stepSummary.Close()
+		if err != nil {
+			log.Error().Err(err).Msg("error closing github step summary file")
+		}
+	}()
+
+	_, err = stepSummary.WriteString(generateMarkdownSummary(processedTestdata))
+	if err != nil {
+		log.Error().Err(err).Msg("error writing github step summary file")
+		return err
+	}
+
+	return nil
+}
+
+// generateMarkdownSummary renders a summary table of the packages followed by a collapsible list of the failing tests
+func generateMarkdownSummary(processedTestdata *ProcessedTestdata) string {
+	var summary bytes.Buffer
+
+	fmt.Fprintf(&summary, "## Go Test Report\n\n")
+	fmt.Fprintf(&summary, "**Passed:** %d | **Failed:** %d | **Skipped:** %d | **Flaky:** %d | **Incomplete:** %d | **Build failed packages:** %d | **Total test time:** %s | **Test date:** %s\n\n",
+		processedTestdata.PassedTests,
+		processedTestdata.FailedTests,
+		processedTestdata.SkippedTests,
+		processedTestdata.FlakyTests,
+		processedTestdata.IncompleteTests,
+		processedTestdata.BuildFailed,
+		processedTestdata.TotalTestTime,
+		processedTestdata.TestDate,
+	)
+	if processedTestdata.TotalCoverage != nil {
+		fmt.Fprintf(&summary, "**Total coverage:** %s of statements\n\n", processedTestdata.TotalCoverage)
+	}
+
+	if len(processedTestdata.CoverageViolations) > 0 {
+		fmt.Fprintf(&summary, "**Packages below their coverage floor:**\n\n")
+		for _, violation := range processedTestdata.CoverageViolations {
+			fmt.Fprintf(&summary, "- `%s`: %.1f%%, floor %.1f%%\n", violation.PackageName, violation.Coverage, violation.Floor)
+		}
+		fmt.Fprintf(&summary, "\n")
+	}
+
+	packageCountsMap := make(map[string]*markdownPackageCounts)
+	failedTests := make([]*TestDetails, 0)
+	for _, test := range processedTestdata.TestSummary {
+		failedTests = countMarkdownTests(packageCountsMap, failedTests, test)
+	}
+
+	packageNames := sortedPackageNames(processedTestdata.PackageDetailsMap)
+
+	fmt.Fprintf(&summary, "| Package | Status | Passed | Failed | Skipped | Flaky | Incomplete | Coverage | Duration |\n")
+	fmt.Fprintf(&summary, "|---|---|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, packageName := range packageNames {
+		packageDetails := processedTestdata.PackageDetailsMap[packageName]
+		packageCounts := packageCountsMap[packageName]
+		if packageCounts == nil {
+			packageCounts = &markdownPackageCounts{}
+		}
+		status := markdownStatus(packageDetails.Status)
+		if packageDetails.BuildFailed {
+			status = "❌ build failed"
+		}
+		fmt.Fprintf(&summary, "| `%s` | %s | %d | %d | %d | %d | %d | %s | %s |\n",
+			packageName,
+			status,
+			packageCounts.passed,
+			packageCounts.failed,
+			packageCounts.skipped,
+			packageCounts.flaky,
+			packageCounts.incomplete,
+			strings.TrimSpace(packageDetails.Coverage),
+			fmt.Sprintf("%v%s", packageDetails.ElapsedTime, packageDetails.TimeSymbol),
+		)
+	}
+
+	for _, packageName := range packageNames {
+		packageDetails := processedTestdata.PackageDetailsMap[packageName]
+		if packageDetails.BuildFailed && len(packageDetails.BuildOutput) > 0 {
+			fmt.Fprintf(&summary, "\n<details>\n<summary>`%s` failed to build</summary>\n\n%s\n\n</details>\n",
+				packageName,
+				markdownCodeBlock(strings.Join(packageDetails.BuildOutput, "\n")),
+			)
+		}
+	}
+
+	if len(failedTests) == 0 {
+		return summary.String()
+	}
+
+	fmt.Fprintf(&summary, "\n<details>\n<summary>%d failing or unfinished tests</summary>\n\n", len(failedTests))
+	for _, test := range failedTests {
+		if test.Status == "fail" {
+			fmt.Fprintf(&summary, "#### `%s` in `%s`\n\n", test.Name, test.PackageName)
+		} else {
+			fmt.Fprintf(&summary, "#### `%s` in `%s` (%s)\n\n", test.Name, test.PackageName, test.Status)
+		}
+		fmt.Fprintf(&summary, "%s\n\n", markdownCodeBlock(markdownFailureExcerpt(test)))
+		if test.Fuzz != nil && test.Fuzz.FailingInput != "" {
+			fmt.Fprintf(&summary, "Failing input written to `%s`, re-run with `%s`\n\n", test.Fuzz.FailingInput, test.Fuzz.RerunCommand)
+		}
+	}
+	fmt.Fprintf(&summary, "</details>\n")
+
+	return summary.String()
+}
+
+// countMarkdownTests counts a test and its subtests per package and collects the failing ones
+func countMarkdownTests(packageCountsMap map[string]*markdownPackageCounts, failedTests []*TestDetails, test *TestDetails) []*TestDetails {
+	packageCounts := packageCountsMap[test.PackageName]
+	if packageCounts == nil {
+		packageCounts = &markdownPackageCounts{}
+		packageCountsMap[test.PackageName] = packageCounts
+	}
+
+	if test.Status == "pass" {
+		packageCounts.passed = packageCounts.passed + 1
+	} else if test.Status == "fail" {
+		packageCounts.failed = packageCounts.failed + 1
+		failedTests = append(failedTests, test)
+	} else if test.Status == "timeout" || test.Status == "panic" || test.Status == "incomplete" {
+		packageCounts.incomplete = packageCounts.incomplete + 1
+		failedTests = append(failedTests, test)
+	} else if test.Status == "skip" {
+		packageCounts.skipped = packageCounts.skipped + 1
+	} else if test.Status == "flaky" {
+		packageCounts.flaky = packageCounts.flaky + 1
+	}
+
+	for _, subtest := range test.Subtests {
+		failedTests = countMarkdownTests(packageCountsMap, failedTests, subtest)
+	}
+
+	return failedTests
+}
+
+// markdownFailureExcerpt returns the failure locations of a test, the head of the goroutine dump of a test that never
+// finished, or the tail of its output when it reported neither
+func markdownFailureExcerpt(test *TestDetails) string {
+	if len(test.Failures) > 0 {
+		failures := make([]string, 0, len(test.Failures))
+		for _, failure := range test.Failures {
+			failures = append(failures, fmt.Sprintf("%s:%d: %s", failure.File, failure.Line, failure.Message))
+		}
+		return strings.Join(failures, "\n")
+	}
+
+	if test.Status != "fail" {
+		dump := test.Dump
+		for i, line := range test.Output {
+			if len(dump) == 0 && strings.HasPrefix(line, "panic:") {
+				dump = test.Output[i:]
+			}
+		}
+		if len(dump) > markdownOutputExcerptLines {
+			dump = dump[:markdownOutputExcerptLines]
+		}
+		if len(dump) > 0 {
+			return strings.Join(dump, "\n")
+		}
+	}
+
+	output := test.Output
+	if len(output) > markdownOutputExcerptLines {
+		output = output[len(output)-markdownOutputExcerptLines:]
+	}
+	return strings.Join(output, "\n")
+}
+
+// markdownCodeBlock fences a block of text with more backticks than any run of backticks inside it
+func markdownCodeBlock(text string) string {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence = fence + "`"
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s", fence, text, fence)
+}
+
+func markdownStatus(status string) string {
+	if status == "pass" {
+		return "✅ pass"
+	} else if status == "fail" {
+		return "❌ fail"
+	}
+
+	return "⚪ " + status
+}
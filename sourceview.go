@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Thatooine/go-test-html-report/assets"
+	"github.com/rs/zerolog/log"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// a line of an annotated source page, State is covered, uncovered, partial or empty for lines without statements
+type sourceLine struct {
+	Number int
+	State  string
+}
+
+// a block boundary of an annotated source page, at the byte offset a block of statements starts or ends
+type sourceBoundary struct {
+	Offset int
+	Start  bool
+	Count  int
+}
+
+// sourcePagePath returns the path of the annotated source page of a file of a cover profile, relative to the report
+func sourcePagePath(fileName string) string {
+	return path.Join("coverage", fileName+".html")
+}
+
+// GenerateSourcePages writes an annotated source page for every file of the cover profiles whose source was found,
+// highlighting the covered and uncovered blocks of statements like go tool cover -html
+func GenerateSourcePages(packageDetailsMap map[string]PackageDetails) error {
+	sourceTemplateData, err := assets.Asset("source-template.html")
+	if err != nil {
+		log.Error().Err(err).Msg("error retrieving source-template.html")
+		return err
+	}
+
+	sourceTemplate, err := template.New("source-template.html").Parse(string(sourceTemplateData))
+	if err != nil {
+		log.Error().Err(err).Msg("error parsing source-template.html")
+		return err
+	}
+
+	for _, packageName := range sortedPackageNames(packageDetailsMap) {
+		for _, fileCoverage := range packageDetailsMap[packageName].Files {
+			if fileCoverage.SourcePage == "" {
+				continue
+			}
+
+			source, err := ioutil.ReadFile(fileCoverage.sourceFile)
+			if err != nil {
+				log.Error().Err(err).Msgf("error reading source file %s", fileCoverage.sourceFile)
+				return err
+			}
+
+			var processedTemplate bytes.Buffer
+			err = sourceTemplate.Execute(&processedTemplate, map[string]interface{}{
+				"FileName":          fileCoverage.FileName,
+				"PackageName":       packageName,
+				"Coverage":          fileCoverage.Coverage,
+				"Statements":        fileCoverage.Statements,
+				"CoveredStatements": fileCoverage.CoveredStatements,
+				"ReportLink":        strings.Repeat("../", strings.Count(fileCoverage.SourcePage, "/")) + "report.html",
+				"Lines":             annotateSourceLines(source, fileCoverage.blocks),
+				"Source":            annotateSource(source, fileCoverage.blocks),
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("error applying source-template.html")
+				return err
+			}
+
+			pagePath := reportPath(filepath.FromSlash(fileCoverage.SourcePage))
+			err = os.MkdirAll(filepath.Dir(pagePath), 0755)
+			if err != nil {
+				log.Error().Err(err).Msg("error creating coverage directory")
+				return err
+			}
+			err = ioutil.WriteFile(pagePath, processedTemplate.Bytes(), 0644)
+			if err != nil {
+				log.Error().Err(err).Msgf("error writing %s file", fileCoverage.SourcePage)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// annotateSource returns the html escaped source with every block of statements wrapped in a span
+// of class covered or uncovered, the blocks of a cover profile never overlap
+func annotateSource(source []byte, blocks []CoverageBlock) template.HTML {
+	lineOffsets := []int{0}
+	for i, b := range source {
+		if b == '\n' {
+			lineOffsets = append(lineOffsets, i+1)
+		}
+	}
+	offset := func(line, column int) int {
+		if line < 1 || line > len(lineOffsets) {
+			return len(source)
+		}
+		if o := lineOffsets[line-1] + column - 1; o < len(source) {
+			return o
+		}
+		return len(source)
+	}
+
+	boundaries := make([]sourceBoundary, 0, 2*len(blocks))
+	for _, block := range blocks {
+		boundaries = append(boundaries,
+			sourceBoundary{Offset: offset(block.StartLine, block.StartCol), Start: true, Count: block.Count},
+			sourceBoundary{Offset: offset(block.EndLine, block.EndCol), Start: false},
+		)
+	}
+	// at the same offset a block ends before the next one starts
+	sort.SliceStable(boundaries, func(i, j int) bool {
+		if boundaries[i].Offset != boundaries[j].Offset {
+			return boundaries[i].Offset < boundaries[j].Offset
+		}
+		return !boundaries[i].Start && boundaries[j].Start
+	})
+
+	var annotated bytes.Buffer
+	last := 0
+	for _, boundary := range boundaries {
+		annotated.WriteString(template.HTMLEscapeString(string(source[last:boundary.Offset])))
+		last = boundary.Offset
+		if !boundary.Start {
+			annotated.WriteString("</span>")
+		} else if boundary.Count > 0 {
+			fmt.Fprintf(&annotated, `<span class="covered" title="executed %d times">`, boundary.Count)
+		} else {
+			annotated.WriteString(`<span class="uncovered" title="not executed">`)
+		}
+	}
+	annotated.WriteString(template.HTMLEscapeString(string(source[last:])))
+
+	return template.HTML(annotated.String())
+}
+
+// annotateSourceLines returns the lines of a source file with the coverage of the blocks on them,
+// a line is partially covered when covered and uncovered blocks share it
+func annotateSourceLines(source []byte, blocks []CoverageBlock) []sourceLine {
+	lineCount := bytes.Count(source, []byte("\n"))
+	if len(source) > 0 && source[len(source)-1] != '\n' {
+		lineCount = lineCount + 1
+	}
+
+	covered := make([]bool, lineCount+1)
+	uncovered := make([]bool, lineCount+1)
+	for _, block := range blocks {
+		// the end column is exclusive, a block ending in column 1 ends with the line before
+		endLine := block.EndLine
+		if block.EndCol <= 1 && endLine > block.StartLine {
+			endLine = endLine - 1
+		}
+		for line := block.StartLine; line <= endLine && line <= lineCount; line++ {
+			if block.Count > 0 {
+				covered[line] = true
+			} else {
+				uncovered[line] = true
+			}
+		}
+	}
+
+	lines := make([]sourceLine, 0, lineCount)
+	for number := 1; number <= lineCount; number++ {
+		line := sourceLine{Number: number}
+		if covered[number] && uncovered[number] {
+			line.State = "partial"
+		} else if covered[number] {
+			line.State = "covered"
+		} else if uncovered[number] {
+			line.State = "uncovered"
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
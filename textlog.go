@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the lines of go test -v that start, pause and resume a test, e.g. "=== RUN   TestAdd"
+var verboseRunLine = regexp.MustCompile(`^=== (RUN|PAUSE|CONT|NAME)\s+(\S+)`)
+
+// the result line of a test or subtest, indented by its depth, e.g. "    --- PASS: TestAdd/small (0.01s)"
+var verboseResultLine = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (\S+) \(([\d.]+)s\)`)
+
+// the result line of a package, e.g. "ok  \texample.com/pkg\t0.004s\tcoverage: 75.0% of statements",
+// "FAIL\texample.com/pkg [build failed]" or "?   \texample.com/pkg\t[no test files]"
+var verbosePackageResultLine = regexp.MustCompile(`^(ok|FAIL|\?)\s*\t(\S+)(.*)$`)
+
+// the elapsed time of a package result line
+var verbosePackageElapsed = regexp.MustCompile(`^\t([\d.]+)s`)
+
+// ParseLogLines parses the lines of a go test log, which is either the json event stream of go test -json or the
+// plain text of go test -v. A log holding any go test json event is a json stream, its other lines, such as the
+// "go: downloading" lines and compiler errors of go test -json 2>&1, become output events. Plain text has no
+// timestamps, its run is taken to have ended at the given time, the time the log was written
+func ParseLogLines(lines []string, logTime time.Time) (*[]GoTestJsonRowData, error) {
+	events := make([]*GoTestJsonRowData, len(lines))
+	jsonEvents := 0
+	for i, line := range lines {
+		if event, ok := parseTestEvent(line); ok {
+			events[i] = event
+			jsonEvents = jsonEvents + 1
+		}
+	}
+
+	var rowData []GoTestJsonRowData
+	if jsonEvents > 0 {
+		rowData = convertStrayLines(lines, events)
+	} else {
+		rowData = ConvertVerboseLog(lines, logTime)
+	}
+	if len(rowData) == 0 {
+		err := fmt.Errorf("no go test events found, expected the output of go test -json or go test -v")
+		log.Error().Err(err).Msg("error parsing go test logs")
+		return nil, err
+	}
+
+	return &rowData, nil
+}
+
+// parseTestEvent parses a line of go test -json, it reports false for lines that are not a go test json event
+func parseTestEvent(line string) (*GoTestJsonRowData, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return nil, false
+	}
+	event := &GoTestJsonRowData{}
+	if err := json.Unmarshal([]byte(line), event); err != nil || event.Action == "" {
+		return nil, false
+	}
+
+	return event, true
+}
+
+// convertStrayLines returns the events of a json stream, turning the lines that are not json events into output
+// events of the package of the package event before them, or of the first package event for the lines at the start.
+// Compiler errors following a "# example.com/pkg" header become build output of that package
+func convertStrayLines(lines []string, events []*GoTestJsonRowData) []GoTestJsonRowData {
+	rowData := make([]GoTestJsonRowData, 0, len(lines))
+	var previous *GoTestJsonRowData
+	for _, event := range events {
+		if event != nil && event.Package != "" {
+			previous = event
+			break
+		}
+	}
+
+	buildImportPath := ""
+	for i, line := range lines {
+		if events[i] != nil {
+			if events[i].Package != "" {
+				previous = events[i]
+			}
+			buildImportPath = ""
+			rowData = append(rowData, *events[i])
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "# ") {
+			buildImportPath = strings.TrimPrefix(line, "# ")
+		}
+		if buildImportPath != "" {
+			rowData = append(rowData, GoTestJsonRowData{Action: "build-output", ImportPath: buildImportPath, Output: line + "\n"})
+			continue
+		}
+		// a stream of build events only has no package to attribute the line to
+		if previous == nil {
+			continue
+		}
+		rowData = append(rowData, GoTestJsonRowData{
+			Time:    previous.Time,
+			Action:  "output",
+			Package: previous.Package,
+			Output:  line + "\n",
+		})
+	}
+
+	return rowData
+}
+
+// ConvertVerboseLog converts the plain text output of go test -v into the events go test -json would have written.
+// The package of a test is only known from the result line of its package, so the events of a package are held
+// back until then. Text logs carry no timestamps, packages are taken to run one after the other until end
+func ConvertVerboseLog(lines []string, end time.Time) []GoTestJsonRowData {
+	rowData := make([]GoTestJsonRowData, 0, len(lines))
+	packageRows := make([]GoTestJsonRowData, 0)
+	clock := end
+	for _, line := range lines {
+		if match := verbosePackageResultLine.FindStringSubmatch(line); match != nil {
+			if elapsedMatch := verbosePackageElapsed.FindStringSubmatch(match[3]); elapsedMatch != nil {
+				elapsed, _ := strconv.ParseFloat(elapsedMatch[1], 64)
+				clock = clock.Add(-time.Duration(elapsed * float64(time.Second)))
+			}
+		}
+	}
+	currentTest := ""
+	buildImportPath := ""
+
+	for _, line := range lines {
+		// compiler errors follow a "# example.com/pkg [example.com/pkg.test]" header until the package result line
+		if strings.HasPrefix(line, "# ") {
+			buildImportPath = strings.TrimPrefix(line, "# ")
+			rowData = append(rowData, GoTestJsonRowData{Action: "build-output", ImportPath: buildImportPath, Output: line + "\n"})
+			continue
+		}
+
+		if match := verbosePackageResultLine.FindStringSubmatch(line); match != nil {
+			packageName := match[2]
+			result := match[3]
+			elapsed := 0.0
+			if elapsedMatch := verbosePackageElapsed.FindStringSubmatch(result); elapsedMatch != nil {
+				elapsed, _ = strconv.ParseFloat(elapsedMatch[1], 64)
+			}
+			packageStart := clock
+			clock = clock.Add(time.Duration(elapsed * float64(time.Second)))
+
+			packageRow := GoTestJsonRowData{Time: clock, Package: packageName, Elapsed: elapsed}
+			if match[1] == "ok" {
+				packageRow.Action = "pass"
+			} else if match[1] == "?" {
+				packageRow.Action = "skip"
+			} else {
+				packageRow.Action = "fail"
+				if strings.HasPrefix(strings.TrimSpace(result), "[build failed]") || strings.HasPrefix(strings.TrimSpace(result), "[setup failed]") {
+					// the compiler errors are those of the package named by the build header, which may be an import
+					packageRow.FailedBuild = packageName
+					if buildImportPath != "" {
+						packageRow.FailedBuild = buildImportPath
+					}
+				}
+			}
+
+			packageRows = append(packageRows, GoTestJsonRowData{Action: "output", Output: line + "\n"})
+			for _, row := range packageRows {
+				row.Package = packageName
+				row.Time = packageStart
+				rowData = append(rowData, row)
+			}
+			rowData = append(rowData, packageRow)
+
+			packageRows = packageRows[:0]
+			currentTest = ""
+			buildImportPath = ""
+			continue
+		}
+
+		if buildImportPath != "" {
+			rowData = append(rowData, GoTestJsonRowData{Action: "build-output", ImportPath: buildImportPath, Output: line + "\n"})
+			continue
+		}
+
+		if match := verboseRunLine.FindStringSubmatch(line); match != nil {
+			currentTest = match[2]
+			if match[1] == "RUN" {
+				packageRows = append(packageRows, GoTestJsonRowData{Action: "run", Test: currentTest})
+			}
+			packageRows = append(packageRows, GoTestJsonRowData{Action: "output", Test: currentTest, Output: line + "\n"})
+			continue
+		}
+
+		if match := verboseResultLine.FindStringSubmatch(line); match != nil {
+			// without -v the log of a failed test is printed below its result line. like test2json the result line
+			// loses the indentation of its depth, which would make it continue the message logged before it
+			currentTest = match[2]
+			elapsed, _ := strconv.ParseFloat(match[3], 64)
+			packageRows = append(packageRows,
+				GoTestJsonRowData{Action: "output", Test: currentTest, Output: strings.TrimLeft(line, " \t") + "\n"},
+				GoTestJsonRowData{Action: strings.ToLower(match[1]), Test: currentTest, Elapsed: elapsed},
+			)
+			continue
+		}
+
+		// the summary lines of a package belong to the package, not to the test that ran last
+		if line == "PASS" || line == "FAIL" || strings.HasPrefix(line, "coverage: ") {
+			currentTest = ""
+		}
+		packageRows = append(packageRows, GoTestJsonRowData{Action: "output", Test: currentTest, Output: line + "\n"})
+	}
+
+	// a log cut short before the result line of its last package, e.g. by a killed test binary
+	for _, row := range packageRows {
+		if row.Test != "" {
+			rowData = append(rowData, packageRows...)
+			break
+		}
+	}
+
+	return rowData
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+var coverageThresholdsFile string
+
+// CoverageThresholds are the coverage floors of the packages, read from a json file such as
+//
+//	{
+//	  "default": 60,
+//	  "packages": [
+//	    {"pattern": "example.com/app/internal/...", "min": 80},
+//	    {"pattern": "example.com/app/cmd/...", "min": 0}
+//	  ]
+//	}
+//
+// Patterns are import paths where ... matches any string, like go list. The longest pattern matching a package
+// sets its floor, packages matching none get the default, which falls back to --min-coverage
+type CoverageThresholds struct {
+	Default  *float64                   `json:"default"`
+	Packages []PackageCoverageThreshold `json:"packages"`
+}
+
+type PackageCoverageThreshold struct {
+	Pattern string  `json:"pattern"`
+	Min     float64 `json:"min"`
+}
+
+type CoverageViolation struct {
+	PackageName string  `json:"package"`
+	Coverage    float64 `json:"coverage"`
+	Floor       float64 `json:"floor"`
+}
+
+// ReadCoverageThresholds reads the coverage thresholds file, without a file every package gets the --min-coverage floor
+func ReadCoverageThresholds(fileName string) (*CoverageThresholds, error) {
+	coverageThresholds := &CoverageThresholds{}
+	if fileName != "" {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			log.Error().Err(err).Msg("error reading coverage thresholds file")
+			return nil, err
+		}
+
+		err = json.Unmarshal(data, coverageThresholds)
+		if err != nil {
+			log.Error().Err(err).Msg("error unmarshalling coverage thresholds")
+			return nil, err
+		}
+
+		for _, packageThreshold := range coverageThresholds.Packages {
+			if packageThreshold.Pattern == "" {
+				err = fmt.Errorf("coverage threshold of %.1f%% in %s has no package pattern", packageThreshold.Min, fileName)
+				log.Error().Err(err).Msg("error reading coverage thresholds")
+				return nil, err
+			}
+		}
+	}
+	if coverageThresholds.Default == nil {
+		coverageThresholds.Default = &minCoverage
+	}
+
+	return coverageThresholds, nil
+}
+
+// Floor returns the minimum coverage percentage of a package
+func (coverageThresholds *CoverageThresholds) Floor(packageName string) float64 {
+	floor := *coverageThresholds.Default
+	longestPattern := -1
+	for _, packageThreshold := range coverageThresholds.Packages {
+		if len(packageThreshold.Pattern) > longestPattern && matchPackagePattern(packageThreshold.Pattern, packageName) {
+			floor = packageThreshold.Min
+			longestPattern = len(packageThreshold.Pattern)
+		}
+	}
+
+	return floor
+}
+
+// matchPackagePattern reports whether an import path matches a pattern, "x/..." also matches x itself
+func matchPackagePattern(pattern, packageName string) bool {
+	expression := strings.Replace(regexp.QuoteMeta(pattern), `\.\.\.`, `.*`, -1)
+	if strings.HasSuffix(expression, `/.*`) {
+		expression = strings.TrimSuffix(expression, `/.*`) + `(/.*)?`
+	}
+
+	return regexp.MustCompile("^" + expression + "$").MatchString(packageName)
+}
+
+// ApplyCoverageThresholds marks the packages whose coverage is below their floor and lists them as violations
+func ApplyCoverageThresholds(processedTestdata *ProcessedTestdata, coverageThresholds *CoverageThresholds) {
+	processedTestdata.CoverageViolations = make([]CoverageViolation, 0)
+	for _, packageName := range sortedPackageNames(processedTestdata.PackageDetailsMap) {
+		packageDetails := processedTestdata.PackageDetailsMap[packageName]
+		packageDetails.CoverageFloor = coverageThresholds.Floor(packageName)
+		packageDetails.BelowCoverageFloor = false
+
+		coverage, ok := packageCoveragePercent(packageDetails)
+		if ok && coverage < packageDetails.CoverageFloor {
+			packageDetails.BelowCoverageFloor = true
+			processedTestdata.CoverageViolations = append(processedTestdata.CoverageViolations, CoverageViolation{
+				PackageName: packageName,
+				Coverage:    coverage,
+				Floor:       packageDetails.CoverageFloor,
+			})
+		}
+		processedTestdata.PackageDetailsMap[packageName] = packageDetails
+	}
+}